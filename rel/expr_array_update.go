@@ -0,0 +1,83 @@
+package rel
+
+import (
+	"fmt"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// ArrayUpdateExpr is `a with [index] = value`: an Array equal to a but with
+// the element at index replaced by value. A negative index counts from the
+// end, as in Python/seq slicing elsewhere in this package. It errors at Eval
+// if index is out of range for a.
+type ArrayUpdateExpr struct {
+	ExprScanner
+	a, index, value Expr
+}
+
+// NewArrayUpdateExpr returns a new ArrayUpdateExpr.
+func NewArrayUpdateExpr(scanner parser.Scanner, a, index, value Expr) Expr {
+	return &ArrayUpdateExpr{ExprScanner{scanner}, a, index, value}
+}
+
+// String returns a string representation of the expression.
+func (e *ArrayUpdateExpr) String() string {
+	return fmt.Sprintf("(%s with [%s] = %s)", e.a, e.index, e.value)
+}
+
+// Eval returns a's elements with the one at index replaced by value.
+func (e *ArrayUpdateExpr) Eval(local Scope) (Value, error) {
+	aVal, err := e.a.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	x, ok := aVal.(Set)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("'with [i] =' lhs must be a Set, not %T", aVal), e, local)
+	}
+	values, ok := arrayUpdateValues(x)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("'with [i] =' lhs must be an Array, not %v", x), e, local)
+	}
+
+	indexVal, err := e.index.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	n, ok := indexVal.(Number)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("'with [i] =' index must be a Number, not %T", indexVal), e, local)
+	}
+	i := int(n.Float64())
+	origI := i
+	if i < 0 {
+		i += len(values)
+	}
+	if i < 0 || i >= len(values) {
+		return nil, WrapContext(
+			errors.Errorf("'with [i] =' index %d out of range for array of length %d", origI, len(values)), e, local)
+	}
+
+	value, err := e.value.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+
+	updated := make([]Value, len(values))
+	copy(updated, values)
+	updated[i] = value
+	return NewArray(updated...), nil
+}
+
+// arrayUpdateValues returns x's elements in index order, and true, iff x is
+// an Array or the empty set (treated as a zero-length array).
+func arrayUpdateValues(x Set) ([]Value, bool) {
+	if a, ok := x.(Array); ok {
+		return a.Values(), true
+	}
+	if x.Count() == 0 {
+		return nil, true
+	}
+	return nil, false
+}
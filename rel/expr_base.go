@@ -0,0 +1,145 @@
+package rel
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+const (
+	minBase = 2
+	maxBase = 36
+)
+
+// NumberFormatBaseError wraps a strconv failure from NumberFormatBaseExpr so
+// it can be caught with `try toBase(...) catch toBase handler`.
+type NumberFormatBaseError struct {
+	ctxErr error
+}
+
+func (e NumberFormatBaseError) Error() string {
+	return e.ctxErr.Error()
+}
+
+// IsNumberFormatBaseError reports whether err is a NumberFormatBaseError, or
+// a ContextErr directly wrapping one.
+func IsNumberFormatBaseError(err error) bool {
+	if ctxErr, ok := err.(ContextErr); ok {
+		_, ok := ctxErr.NextErr().(NumberFormatBaseError)
+		return ok
+	}
+	_, ok := err.(NumberFormatBaseError)
+	return ok
+}
+
+// NumberFormatBaseExpr is `toBase(n, base)`: n rendered as a string in the
+// given base, 2-36. base must be in range 2-36; a base that's statically
+// known to be out of range is a compile error rather than a runtime one. n
+// must be a whole number.
+type NumberFormatBaseExpr struct {
+	ExprScanner
+	n, base Expr
+}
+
+// NewNumberFormatBaseExpr returns a new NumberFormatBaseExpr.
+func NewNumberFormatBaseExpr(scanner parser.Scanner, n, base Expr) Expr {
+	return &NumberFormatBaseExpr{ExprScanner{scanner}, n, base}
+}
+
+// String returns a string representation of the expression.
+func (e *NumberFormatBaseExpr) String() string {
+	return fmt.Sprintf("toBase(%s, %s)", e.n, e.base)
+}
+
+// Eval renders n as a string in the given base.
+func (e *NumberFormatBaseExpr) Eval(local Scope) (Value, error) {
+	nVal, err := e.n.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	nNum, ok := nVal.(Number)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("toBase: n must be a Number, not %T", nVal), e, local)
+	}
+	n, whole := nNum.Int()
+	if !whole {
+		return nil, WrapContext(errors.Errorf("toBase: n must be a whole number, not %v", nNum), e, local)
+	}
+
+	base, err := evalBase(e.base, local, "toBase")
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+
+	return NewString([]rune(strconv.FormatInt(int64(n), base))), nil
+}
+
+// NumberParseBaseExpr is `fromBase(s, base)`: the inverse of
+// NumberFormatBaseExpr, parsing String s as an integer in the given base,
+// 2-36. base must be in range 2-36; a base that's statically known to be out
+// of range is a compile error rather than a runtime one. An s with invalid
+// digits for base produces a catchable NumberFormatBaseError instead of
+// panicking.
+type NumberParseBaseExpr struct {
+	ExprScanner
+	s, base Expr
+}
+
+// NewNumberParseBaseExpr returns a new NumberParseBaseExpr.
+func NewNumberParseBaseExpr(scanner parser.Scanner, s, base Expr) Expr {
+	return &NumberParseBaseExpr{ExprScanner{scanner}, s, base}
+}
+
+// String returns a string representation of the expression.
+func (e *NumberParseBaseExpr) String() string {
+	return fmt.Sprintf("fromBase(%s, %s)", e.s, e.base)
+}
+
+// Eval parses s as an integer in the given base, returning a
+// NumberFormatBaseError on failure.
+func (e *NumberParseBaseExpr) Eval(local Scope) (Value, error) {
+	sVal, err := e.s.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	sSet, ok := sVal.(Set)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("fromBase: s must be a String, not %T", sVal), e, local)
+	}
+	s, ok := AsString(sSet)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("fromBase: s must be a String, not %T", sVal), e, local)
+	}
+
+	base, err := evalBase(e.base, local, "fromBase")
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+
+	n, err := strconv.ParseInt(s.String(), base, 64)
+	if err != nil {
+		return nil, WrapContext(NumberFormatBaseError{errors.Errorf("fromBase: %s", err)}, e, local)
+	}
+
+	return NewNumber(float64(n)), nil
+}
+
+// evalBase evaluates base and validates it is a whole number in [minBase,
+// maxBase], returning it as an int suitable for strconv.
+func evalBase(base Expr, local Scope, name string) (int, error) {
+	baseVal, err := base.Eval(local)
+	if err != nil {
+		return 0, err
+	}
+	baseNum, ok := baseVal.(Number)
+	if !ok {
+		return 0, errors.Errorf("%s: base must be a Number, not %T", name, baseVal)
+	}
+	n, whole := baseNum.Int()
+	if !whole || n < minBase || n > maxBase {
+		return 0, errors.Errorf("%s: base must be a whole number in [%d, %d], not %v", name, minBase, maxBase, baseNum)
+	}
+	return n, nil
+}
@@ -0,0 +1,102 @@
+package rel
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// Base64DecodeError wraps a malformed-input failure from a decoding
+// Base64Expr so it can be caught with
+// `try base64decode(...) catch base64Decode handler`.
+type Base64DecodeError struct {
+	ctxErr error
+}
+
+func (b Base64DecodeError) Error() string {
+	return b.ctxErr.Error()
+}
+
+// IsBase64DecodeError reports whether err is a Base64DecodeError, or a
+// ContextErr directly wrapping one, e.g. as produced by a decoding
+// Base64Expr failing on malformed input.
+func IsBase64DecodeError(err error) bool {
+	if ctxErr, ok := err.(ContextErr); ok {
+		_, ok := ctxErr.NextErr().(Base64DecodeError)
+		return ok
+	}
+	_, ok := err.(Base64DecodeError)
+	return ok
+}
+
+// Base64Expr is `base64encode(value, urlSafe)`, converting Bytes to a
+// base64-encoded String, or `base64decode(value, urlSafe)`, its inverse,
+// converting a base64-encoded String back to Bytes. urlSafe selects the
+// URL-safe alphabet (RFC 4648 section 5) over the standard one. Malformed
+// input to base64decode produces a catchable Base64DecodeError rather than
+// panicking.
+type Base64Expr struct {
+	ExprScanner
+	decode         bool
+	value, urlSafe Expr
+}
+
+// NewBase64Expr returns a new Base64Expr. decode selects base64decode (true)
+// or base64encode (false).
+func NewBase64Expr(scanner parser.Scanner, decode bool, value, urlSafe Expr) Expr {
+	return &Base64Expr{ExprScanner{scanner}, decode, value, urlSafe}
+}
+
+func (e *Base64Expr) name() string {
+	if e.decode {
+		return "base64decode"
+	}
+	return "base64encode"
+}
+
+// String returns a string representation of the expression.
+func (e *Base64Expr) String() string {
+	return fmt.Sprintf("%s(%s, %s)", e.name(), e.value, e.urlSafe)
+}
+
+// Eval encodes value to, or decodes it from, base64.
+func (e *Base64Expr) Eval(local Scope) (Value, error) {
+	urlSafeVal, err := e.urlSafe.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	encoding := base64.StdEncoding
+	if urlSafeVal.IsTrue() {
+		encoding = base64.URLEncoding
+	}
+
+	valueVal, err := e.value.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	valueSet, ok := valueVal.(Set)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("%s value must be a Set, not %T", e.name(), valueVal), e, local)
+	}
+
+	if e.decode {
+		s, ok := AsString(valueSet)
+		if !ok {
+			return nil, WrapContext(errors.Errorf("%s value must be a String, not %T", e.name(), valueVal), e, local)
+		}
+		decoded, err := encoding.DecodeString(s.String())
+		if err != nil {
+			return nil, WrapContext(
+				Base64DecodeError{errors.Errorf("%s: %s", e.name(), err)}, e, local)
+		}
+		return NewBytes(decoded), nil
+	}
+
+	b, ok := AsBytes(valueSet)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("%s value must be Bytes, not %T", e.name(), valueVal), e, local)
+	}
+	return NewString([]rune(encoding.EncodeToString(b.Bytes()))), nil
+}
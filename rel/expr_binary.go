@@ -156,7 +156,7 @@ func NewWithoutExpr(scanner parser.Scanner, a, b Expr) Expr {
 // NewWhereExpr evaluates a where pred, given a set lhs.
 func NewWhereExpr(scanner parser.Scanner, a, pred Expr) Expr {
 	pred = ExprAsFunction(pred)
-	return newBinExpr(scanner, a, pred, "where", "(%s where %s)",
+	whereExpr := newBinExpr(scanner, a, pred, "where", "(%s where %s)",
 		func(a, pred Value, local Scope) (Value, error) {
 			if x, ok := a.(Set); ok {
 				if p, ok := pred.(Closure); ok {
@@ -172,6 +172,91 @@ func NewWhereExpr(scanner parser.Scanner, a, pred Expr) Expr {
 			}
 			return nil, errors.Errorf("'where' lhs must be a Set, not %T", a)
 		})
+	if fn, ok := pred.(*Function); ok {
+		if attr, allowed, ok := detectWhereIn(fn); ok {
+			return NewWhereInExpr(whereExpr, a, attr, allowed)
+		}
+	}
+	return whereExpr
+}
+
+// NewCountWhereExpr evaluates a countWhere pred, given a set lhs, returning
+// the number of elements of a satisfying pred. Unlike `(a where pred) count`,
+// it counts in a single pass without materializing the filtered set.
+func NewCountWhereExpr(scanner parser.Scanner, a, pred Expr) Expr {
+	pred = ExprAsFunction(pred)
+	return newBinExpr(scanner, a, pred, "countWhere", "(%s countWhere %s)",
+		func(a, pred Value, local Scope) (Value, error) {
+			if x, ok := a.(Set); ok {
+				if p, ok := pred.(Closure); ok {
+					count := 0
+					for e := x.Enumerator(); e.MoveNext(); {
+						r, err := SetCall(p, e.Current())
+						if err != nil {
+							return nil, err
+						}
+						if r.IsTrue() {
+							count++
+						}
+					}
+					return NewNumber(float64(count)), nil
+				}
+				return nil, errors.Errorf("'countWhere' rhs must be a Fn, not %T", pred)
+			}
+			return nil, errors.Errorf("'countWhere' lhs must be a Set, not %T", a)
+		})
+}
+
+// NewAnyExpr evaluates a any pred, given a set lhs, returning whether any
+// element of a satisfies pred. It short-circuits: evaluation stops as soon
+// as a satisfying element is found, and an empty a is false.
+func NewAnyExpr(scanner parser.Scanner, a, pred Expr) Expr {
+	pred = ExprAsFunction(pred)
+	return newBinExpr(scanner, a, pred, "any", "(%s any %s)",
+		func(a, pred Value, local Scope) (Value, error) {
+			if x, ok := a.(Set); ok {
+				if p, ok := pred.(Closure); ok {
+					for e := x.Enumerator(); e.MoveNext(); {
+						r, err := SetCall(p, e.Current())
+						if err != nil {
+							return nil, err
+						}
+						if r.IsTrue() {
+							return NewBool(true), nil
+						}
+					}
+					return NewBool(false), nil
+				}
+				return nil, errors.Errorf("'any' rhs must be a Fn, not %T", pred)
+			}
+			return nil, errors.Errorf("'any' lhs must be a Set, not %T", a)
+		})
+}
+
+// NewAllExpr evaluates a all pred, given a set lhs, returning whether every
+// element of a satisfies pred. It short-circuits: evaluation stops as soon
+// as a non-satisfying element is found, and an empty a is true.
+func NewAllExpr(scanner parser.Scanner, a, pred Expr) Expr {
+	pred = ExprAsFunction(pred)
+	return newBinExpr(scanner, a, pred, "all", "(%s all %s)",
+		func(a, pred Value, local Scope) (Value, error) {
+			if x, ok := a.(Set); ok {
+				if p, ok := pred.(Closure); ok {
+					for e := x.Enumerator(); e.MoveNext(); {
+						r, err := SetCall(p, e.Current())
+						if err != nil {
+							return nil, err
+						}
+						if !r.IsTrue() {
+							return NewBool(false), nil
+						}
+					}
+					return NewBool(true), nil
+				}
+				return nil, errors.Errorf("'all' rhs must be a Fn, not %T", pred)
+			}
+			return nil, errors.Errorf("'all' lhs must be a Set, not %T", a)
+		})
 }
 
 // NewOrderByExpr evaluates a orderby key, given a set lhs, returning an array.
@@ -255,6 +340,211 @@ func NewRankExpr(scanner parser.Scanner, a, key Expr) Expr {
 		})
 }
 
+// NewCountByExpr evaluates a histogram key, given a relation lhs, returning
+// a dict from each distinct key value to the number of a's elements that
+// produced it. An empty lhs yields an empty dict.
+func NewCountByExpr(scanner parser.Scanner, a, key Expr) Expr {
+	key = ExprAsFunction(key)
+	return newBinExpr(scanner, a, key, "histogram", "(%s histogram %s)",
+		func(a, key Value, local Scope) (Value, error) {
+			x, ok := a.(Set)
+			if !ok {
+				return nil, errors.Errorf("'histogram' lhs must be a Set, not %T", a)
+			}
+			k, ok := key.(Closure)
+			if !ok {
+				return nil, errors.Errorf("'histogram' rhs must be a Fn, not %T", key)
+			}
+
+			var keys []Value
+			var counts []int
+			for e := x.Enumerator(); e.MoveNext(); {
+				kv, err := SetCall(k, e.Current())
+				if err != nil {
+					return nil, err
+				}
+				idx := -1
+				for i, existing := range keys {
+					if existing.Equal(kv) {
+						idx = i
+						break
+					}
+				}
+				if idx == -1 {
+					keys = append(keys, kv)
+					counts = append(counts, 0)
+					idx = len(keys) - 1
+				}
+				counts[idx]++
+			}
+
+			entries := make([]Value, 0, len(keys))
+			for i, kv := range keys {
+				entries = append(entries, NewDictEntryTuple(kv, NewNumber(float64(counts[i]))))
+			}
+			return NewSet(entries...), nil
+		})
+}
+
+// NewPartitionExpr evaluates a partition pred, given a Set (or Array) lhs,
+// returning (yes: ..., no: ...): the elements satisfying pred and those
+// that don't, split in a single pass over a rather than two separate
+// `where` scans. a's kind is preserved in both parts: an Array lhs yields
+// two Arrays, each retaining the relative order of a's elements; any other
+// Set lhs yields two Sets.
+func NewPartitionExpr(scanner parser.Scanner, a, pred Expr) Expr {
+	pred = ExprAsFunction(pred)
+	return newBinExpr(scanner, a, pred, "partition", "(%s partition %s)",
+		func(a, pred Value, local Scope) (Value, error) {
+			p, ok := pred.(Closure)
+			if !ok {
+				return nil, errors.Errorf("'partition' rhs must be a Fn, not %T", pred)
+			}
+
+			split := func(v Value) (bool, error) {
+				r, err := SetCall(p, v)
+				if err != nil {
+					return false, err
+				}
+				return r.IsTrue(), nil
+			}
+
+			if arr, ok := a.(Array); ok {
+				var yes, no []Value
+				for _, v := range arr.Values() {
+					match, err := split(v)
+					if err != nil {
+						return nil, err
+					}
+					if match {
+						yes = append(yes, v)
+					} else {
+						no = append(no, v)
+					}
+				}
+				return NewTuple(NewAttr("yes", NewArray(yes...)), NewAttr("no", NewArray(no...))), nil
+			}
+
+			x, ok := a.(Set)
+			if !ok {
+				return nil, errors.Errorf("'partition' lhs must be a Set, not %T", a)
+			}
+			var yes, no []Value
+			for e := x.Enumerator(); e.MoveNext(); {
+				v := e.Current()
+				match, err := split(v)
+				if err != nil {
+					return nil, err
+				}
+				if match {
+					yes = append(yes, v)
+				} else {
+					no = append(no, v)
+				}
+			}
+			return NewTuple(NewAttr("yes", NewSet(yes...)), NewAttr("no", NewSet(no...))), nil
+		})
+}
+
+// NewTakeWhileExpr evaluates a takeWhile pred, given an Array lhs, returning
+// a new Array of a's leading elements up to but excluding the first one that
+// fails pred. A Set has no defined element order, so "leading" would be
+// ambiguous; only an Array lhs is accepted.
+func NewTakeWhileExpr(scanner parser.Scanner, a, pred Expr) Expr {
+	pred = ExprAsFunction(pred)
+	return newBinExpr(scanner, a, pred, "takeWhile", "(%s takeWhile %s)",
+		func(a, pred Value, local Scope) (Value, error) {
+			arr, ok := a.(Array)
+			if !ok {
+				return nil, errors.Errorf("'takeWhile' lhs must be an ordered Array, not %T", a)
+			}
+			p, ok := pred.(Closure)
+			if !ok {
+				return nil, errors.Errorf("'takeWhile' rhs must be a Fn, not %T", pred)
+			}
+
+			var values []Value
+			for _, v := range arr.Values() {
+				r, err := SetCall(p, v)
+				if err != nil {
+					return nil, err
+				}
+				if !r.IsTrue() {
+					break
+				}
+				values = append(values, v)
+			}
+			return NewArray(values...), nil
+		})
+}
+
+// NewDropWhileExpr evaluates a dropWhile pred, given an Array lhs, returning
+// a new Array of a's elements from the first one that fails pred onwards. A
+// Set has no defined element order, so "from" would be ambiguous; only an
+// Array lhs is accepted.
+func NewDropWhileExpr(scanner parser.Scanner, a, pred Expr) Expr {
+	pred = ExprAsFunction(pred)
+	return newBinExpr(scanner, a, pred, "dropWhile", "(%s dropWhile %s)",
+		func(a, pred Value, local Scope) (Value, error) {
+			arr, ok := a.(Array)
+			if !ok {
+				return nil, errors.Errorf("'dropWhile' lhs must be an ordered Array, not %T", a)
+			}
+			p, ok := pred.(Closure)
+			if !ok {
+				return nil, errors.Errorf("'dropWhile' rhs must be a Fn, not %T", pred)
+			}
+
+			values := arr.Values()
+			i := 0
+			for ; i < len(values); i++ {
+				r, err := SetCall(p, values[i])
+				if err != nil {
+					return nil, err
+				}
+				if !r.IsTrue() {
+					break
+				}
+			}
+			return NewArray(values[i:]...), nil
+		})
+}
+
+// NewUniqueByExpr evaluates a distinctby key, given an Array lhs, returning a
+// new Array retaining, for each distinct key value, only the first of a's
+// elements to produce it. A Set has no defined element order, so "first"
+// would be ambiguous; only an Array lhs is accepted (a String or Bytes lhs
+// is rejected too).
+func NewUniqueByExpr(scanner parser.Scanner, a, key Expr) Expr {
+	key = ExprAsFunction(key)
+	return newBinExpr(scanner, a, key, "distinctby", "(%s distinctby %s)",
+		func(a, key Value, local Scope) (Value, error) {
+			arr, ok := a.(Array)
+			if !ok {
+				return nil, errors.Errorf(
+					"'distinctby' lhs must be an ordered Array, not %T", a)
+			}
+			k, ok := key.(Closure)
+			if !ok {
+				return nil, errors.Errorf("'distinctby' rhs must be a Fn, not %T", key)
+			}
+			seen := None
+			values := make([]Value, 0, len(arr.values))
+			for _, v := range arr.values {
+				key, err := SetCall(k, v)
+				if err != nil {
+					return nil, err
+				}
+				if seen.Has(key) {
+					continue
+				}
+				seen = seen.With(key)
+				values = append(values, v)
+			}
+			return NewArray(values...), nil
+		})
+}
+
 func Call(a, b Value, _ Scope) (Value, error) {
 	if x, ok := a.(Set); ok {
 		return SetCall(x, b)
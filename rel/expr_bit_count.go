@@ -0,0 +1,75 @@
+package rel
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// BitCountExpr is `popcount(n)`, `leadingZeros(n)` or `trailingZeros(n)`:
+// counts bits in n's 64-bit unsigned integer representation. n must be a
+// non-negative whole number; a non-integer or negative n is an error.
+type BitCountExpr struct {
+	ExprScanner
+	class string
+	n     Expr
+}
+
+func newBitCountExpr(scanner parser.Scanner, class string, n Expr) Expr {
+	return &BitCountExpr{ExprScanner{scanner}, class, n}
+}
+
+// NewBitCountExpr returns a new BitCountExpr evaluating `popcount(n)`.
+func NewBitCountExpr(scanner parser.Scanner, n Expr) Expr {
+	return newBitCountExpr(scanner, "popcount", n)
+}
+
+// NewLeadingZerosExpr returns a new BitCountExpr evaluating
+// `leadingZeros(n)`.
+func NewLeadingZerosExpr(scanner parser.Scanner, n Expr) Expr {
+	return newBitCountExpr(scanner, "leadingZeros", n)
+}
+
+// NewTrailingZerosExpr returns a new BitCountExpr evaluating
+// `trailingZeros(n)`.
+func NewTrailingZerosExpr(scanner parser.Scanner, n Expr) Expr {
+	return newBitCountExpr(scanner, "trailingZeros", n)
+}
+
+// String returns a string representation of the expression.
+func (e *BitCountExpr) String() string {
+	return fmt.Sprintf("%s(%s)", e.class, e.n)
+}
+
+// Eval evaluates n and computes e.class's bit count.
+func (e *BitCountExpr) Eval(local Scope) (Value, error) {
+	nVal, err := e.n.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	nNum, ok := nVal.(Number)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("%s: n must be a Number, not %T", e.class, nVal), e, local)
+	}
+	n, whole := nNum.Int()
+	if !whole || n < 0 {
+		return nil, WrapContext(
+			errors.Errorf("%s: n must be a non-negative whole number, not %v", e.class, nNum), e, local,
+		)
+	}
+
+	var result int
+	switch e.class {
+	case "popcount":
+		result = bits.OnesCount64(uint64(n))
+	case "leadingZeros":
+		result = bits.LeadingZeros64(uint64(n))
+	case "trailingZeros":
+		result = bits.TrailingZeros64(uint64(n))
+	default:
+		return nil, WrapContext(errors.Errorf("%s: unknown bit count", e.class), e, local)
+	}
+	return NewNumber(float64(result)), nil
+}
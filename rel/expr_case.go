@@ -0,0 +1,74 @@
+package rel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// CaseExpr is `upper(s)`, `lower(s)` or `title(s)`: s converted to the
+// named case, using Go's per-rune Unicode case mapping (so accented letters
+// such as "é"/"É" convert correctly), not just ASCII. Note this is simple
+// case mapping, not full Unicode case folding: a rune with no single-rune
+// uppercase form, like "ß", is left unchanged rather than expanded (e.g. to
+// "SS").
+type CaseExpr struct {
+	ExprScanner
+	class string
+	s     Expr
+}
+
+func newCaseExpr(scanner parser.Scanner, class string, s Expr) Expr {
+	return &CaseExpr{ExprScanner{scanner}, class, s}
+}
+
+// NewUpperExpr returns a new CaseExpr evaluating `upper(s)`.
+func NewUpperExpr(scanner parser.Scanner, s Expr) Expr {
+	return newCaseExpr(scanner, "upper", s)
+}
+
+// NewLowerExpr returns a new CaseExpr evaluating `lower(s)`.
+func NewLowerExpr(scanner parser.Scanner, s Expr) Expr {
+	return newCaseExpr(scanner, "lower", s)
+}
+
+// NewTitleCaseExpr returns a new CaseExpr evaluating `title(s)`.
+func NewTitleCaseExpr(scanner parser.Scanner, s Expr) Expr {
+	return newCaseExpr(scanner, "title", s)
+}
+
+// String returns a string representation of the expression.
+func (e *CaseExpr) String() string {
+	return fmt.Sprintf("%s(%s)", e.class, e.s)
+}
+
+// Eval evaluates s and converts it to e.class's case.
+func (e *CaseExpr) Eval(local Scope) (Value, error) {
+	sVal, err := e.s.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	sSet, ok := sVal.(Set)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("%s: s must be a String, not %T", e.class, sVal), e, local)
+	}
+	s, ok := AsString(sSet)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("%s: s must be a String, not %T", e.class, sVal), e, local)
+	}
+
+	var result string
+	switch e.class {
+	case "upper":
+		result = strings.ToUpper(s.String())
+	case "lower":
+		result = strings.ToLower(s.String())
+	case "title":
+		result = strings.Title(s.String())
+	default:
+		return nil, WrapContext(errors.Errorf("%s: unknown case", e.class), e, local)
+	}
+	return NewString([]rune(result)), nil
+}
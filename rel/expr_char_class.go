@@ -0,0 +1,77 @@
+package rel
+
+import (
+	"fmt"
+	"unicode"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// CharClassExpr is `isDigit(c)`, `isLetter(c)` or `isSpace(c)`: tests c, a
+// single char (a Number holding a rune) or a one-rune String, against the
+// named Unicode category. A String holding more than one rune is an error.
+type CharClassExpr struct {
+	ExprScanner
+	class string
+	c     Expr
+}
+
+// NewCharClassExpr returns a new CharClassExpr. class must be one of
+// "isDigit", "isLetter" or "isSpace".
+func NewCharClassExpr(scanner parser.Scanner, class string, c Expr) Expr {
+	return &CharClassExpr{ExprScanner{scanner}, class, c}
+}
+
+// String returns a string representation of the expression.
+func (e *CharClassExpr) String() string {
+	return fmt.Sprintf("%s(%s)", e.class, e.c)
+}
+
+// Eval evaluates c to a rune and tests it against e.class's Unicode
+// category.
+func (e *CharClassExpr) Eval(local Scope) (Value, error) {
+	v, err := e.c.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+
+	r, err := charClassRune(v)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+
+	var is bool
+	switch e.class {
+	case "isDigit":
+		is = unicode.IsDigit(r)
+	case "isLetter":
+		is = unicode.IsLetter(r)
+	case "isSpace":
+		is = unicode.IsSpace(r)
+	default:
+		return nil, WrapContext(errors.Errorf("%s: unknown char class", e.class), e, local)
+	}
+	return NewBool(is), nil
+}
+
+// charClassRune extracts the single rune that v represents: a Number
+// holding a rune's code point, or a String of exactly one rune.
+func charClassRune(v Value) (rune, error) {
+	switch v := v.(type) {
+	case Number:
+		n, ok := v.Int()
+		if !ok {
+			return 0, errors.Errorf("char class: char must be a whole number, not %v", v)
+		}
+		return rune(n), nil
+	case Set:
+		if s, ok := AsString(v); ok {
+			if len(s.s) != 1 {
+				return 0, errors.Errorf("char class: %q is not a single char", s.String())
+			}
+			return s.s[0], nil
+		}
+	}
+	return 0, errors.Errorf("char class: char must be a number or a one-rune string, not %T", v)
+}
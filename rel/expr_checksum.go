@@ -0,0 +1,92 @@
+package rel
+
+import (
+	"crypto/md5"  //nolint:gosec
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// ChecksumExpr is `sha256(bytes)`, `md5(bytes)` or `crc32(bytes)`: the
+// hex-string digest of bytes, which may be a Bytes value or a String (taken
+// as its UTF-8 encoding).
+type ChecksumExpr struct {
+	ExprScanner
+	algorithm string
+	bytes     Expr
+}
+
+func newChecksumExpr(scanner parser.Scanner, algorithm string, bytes Expr) Expr {
+	return &ChecksumExpr{ExprScanner{scanner}, algorithm, bytes}
+}
+
+// NewChecksumExpr returns a new ChecksumExpr evaluating `sha256(bytes)`.
+func NewChecksumExpr(scanner parser.Scanner, bytes Expr) Expr {
+	return newChecksumExpr(scanner, "sha256", bytes)
+}
+
+// NewMD5ChecksumExpr returns a new ChecksumExpr evaluating `md5(bytes)`.
+func NewMD5ChecksumExpr(scanner parser.Scanner, bytes Expr) Expr {
+	return newChecksumExpr(scanner, "md5", bytes)
+}
+
+// NewCRC32ChecksumExpr returns a new ChecksumExpr evaluating
+// `crc32(bytes)`.
+func NewCRC32ChecksumExpr(scanner parser.Scanner, bytes Expr) Expr {
+	return newChecksumExpr(scanner, "crc32", bytes)
+}
+
+// String returns a string representation of the expression.
+func (e *ChecksumExpr) String() string {
+	return fmt.Sprintf("%s(%s)", e.algorithm, e.bytes)
+}
+
+// Eval evaluates bytes and computes its e.algorithm digest.
+func (e *ChecksumExpr) Eval(local Scope) (Value, error) {
+	bytesVal, err := e.bytes.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	bytesSet, ok := bytesVal.(Set)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("%s: bytes must be Bytes or a String, not %T", e.algorithm, bytesVal),
+			e, local)
+	}
+	b, ok := asBytesOrString(bytesSet)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("%s: bytes must be Bytes or a String, not %T", e.algorithm, bytesVal),
+			e, local)
+	}
+
+	var digest string
+	switch e.algorithm {
+	case "sha256":
+		sum := sha256.Sum256(b)
+		digest = hex.EncodeToString(sum[:])
+	case "md5":
+		sum := md5.Sum(b) //nolint:gosec
+		digest = hex.EncodeToString(sum[:])
+	case "crc32":
+		sum := crc32.ChecksumIEEE(b)
+		digest = fmt.Sprintf("%08x", sum)
+	default:
+		return nil, WrapContext(errors.Errorf("%s: unknown checksum algorithm", e.algorithm), e, local)
+	}
+	return NewString([]rune(digest)), nil
+}
+
+// asBytesOrString returns s's raw bytes, whether s is a Bytes value or a
+// String (taken as its UTF-8 encoding).
+func asBytesOrString(s Set) ([]byte, bool) {
+	if b, ok := AsBytes(s); ok {
+		return b.Bytes(), true
+	}
+	if str, ok := AsString(s); ok {
+		return []byte(str.String()), true
+	}
+	return nil, false
+}
@@ -0,0 +1,113 @@
+package rel
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// ClockFormatExpr is `formatTime(t, layout)`: formats t, a Number of
+// seconds since the Unix epoch (as produced by `now()`), as a String per
+// layout, a Go reference-time layout (e.g. "2006-01-02T15:04:05Z07:00").
+// Formatting is done in UTC.
+type ClockFormatExpr struct {
+	ExprScanner
+	t, layout Expr
+}
+
+// NewClockFormatExpr returns a new ClockFormatExpr.
+func NewClockFormatExpr(scanner parser.Scanner, t, layout Expr) Expr {
+	return &ClockFormatExpr{ExprScanner{scanner}, t, layout}
+}
+
+// String returns a string representation of the expression.
+func (e *ClockFormatExpr) String() string {
+	return fmt.Sprintf("formatTime(%s, %s)", e.t, e.layout)
+}
+
+// Eval evaluates t and layout, then formats t per layout.
+func (e *ClockFormatExpr) Eval(local Scope) (Value, error) {
+	tVal, err := e.t.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	tNum, ok := tVal.(Number)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("formatTime: t must be a number, not %T", tVal), e, local)
+	}
+
+	layout, err := evalString(e.layout, local, "formatTime")
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+
+	sec := float64(tNum)
+	whole := int64(sec)
+	nsec := int64((sec - float64(whole)) * 1e9)
+	formatted := time.Unix(whole, nsec).UTC().Format(layout)
+
+	return NewString([]rune(formatted)), nil
+}
+
+// ClockParseError wraps a time.Parse failure from ClockParseExpr so it can
+// be caught with `try parseTime(...) catch parseTime handler`.
+type ClockParseError struct {
+	ctxErr error
+}
+
+func (p ClockParseError) Error() string {
+	return p.ctxErr.Error()
+}
+
+// IsClockParseError reports whether err is a ClockParseError, or a
+// ContextErr directly wrapping one, e.g. as produced by ClockParseExpr
+// failing to parse its input.
+func IsClockParseError(err error) bool {
+	if ctxErr, ok := err.(ContextErr); ok {
+		_, ok := ctxErr.NextErr().(ClockParseError)
+		return ok
+	}
+	_, ok := err.(ClockParseError)
+	return ok
+}
+
+// ClockParseExpr is `parseTime(s, layout)`: parses String s per layout, a Go
+// reference-time layout, returning a Number of seconds since the Unix epoch
+// (as accepted by `formatTime`). A malformed s produces a catchable
+// ClockParseError instead of panicking.
+type ClockParseExpr struct {
+	ExprScanner
+	s, layout Expr
+}
+
+// NewClockParseExpr returns a new ClockParseExpr.
+func NewClockParseExpr(scanner parser.Scanner, s, layout Expr) Expr {
+	return &ClockParseExpr{ExprScanner{scanner}, s, layout}
+}
+
+// String returns a string representation of the expression.
+func (e *ClockParseExpr) String() string {
+	return fmt.Sprintf("parseTime(%s, %s)", e.s, e.layout)
+}
+
+// Eval evaluates s and layout, then parses s per layout, returning a
+// ClockParseError on failure.
+func (e *ClockParseExpr) Eval(local Scope) (Value, error) {
+	s, err := evalString(e.s, local, "parseTime")
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	layout, err := evalString(e.layout, local, "parseTime")
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+
+	t, err := time.Parse(layout, s)
+	if err != nil {
+		return nil, WrapContext(ClockParseError{errors.Errorf("parseTime: %s", err)}, e, local)
+	}
+
+	return NewNumber(float64(t.UnixNano()) / 1e9), nil
+}
@@ -0,0 +1,67 @@
+package rel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// ContainsExpr is `a contains b`: for a String a, whether b (also a String)
+// occurs as a substring; for any other Set a, whether b is a member.
+type ContainsExpr struct {
+	ExprScanner
+	a, b Expr
+}
+
+// NewContainsExpr returns a new ContainsExpr.
+func NewContainsExpr(scanner parser.Scanner, a, b Expr) Expr {
+	return &ContainsExpr{ExprScanner{scanner}, a, b}
+}
+
+// String returns a string representation of the expression.
+func (e *ContainsExpr) String() string {
+	return fmt.Sprintf("(%s contains %s)", e.a, e.b)
+}
+
+// Eval evaluates a and b, then tests substring or element containment,
+// dispatching on a's kind.
+func (e *ContainsExpr) Eval(local Scope) (Value, error) {
+	aVal, err := e.a.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	a, ok := aVal.(Set)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("contains: a must be a Set, not %T", aVal), e, local)
+	}
+
+	b, err := e.b.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+
+	if aStr, ok := AsString(a); ok {
+		bSet, ok := b.(Set)
+		if !ok {
+			return nil, WrapContext(errors.Errorf("contains: a string can only contain a string, not %T", b), e, local)
+		}
+		bStr, ok := AsString(bSet)
+		if !ok {
+			return nil, WrapContext(errors.Errorf("contains: a string can only contain a string, not %T", b), e, local)
+		}
+		return NewBool(strings.Contains(aStr.String(), bStr.String())), nil
+	}
+
+	if aArr, ok := a.(Array); ok {
+		for _, v := range aArr.Values() {
+			if v.Equal(b) {
+				return NewBool(true), nil
+			}
+		}
+		return NewBool(false), nil
+	}
+
+	return NewBool(a.Has(b)), nil
+}
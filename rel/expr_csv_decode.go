@@ -0,0 +1,125 @@
+package rel
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// CSVDecodeError wraps a malformed-row failure from a CSVDecodeExpr so it can
+// be caught with `try csvDecode(...) catch csvDecode handler`.
+type CSVDecodeError struct {
+	ctxErr error
+}
+
+func (c CSVDecodeError) Error() string {
+	return c.ctxErr.Error()
+}
+
+// IsCSVDecodeError reports whether err is a CSVDecodeError, or a ContextErr
+// directly wrapping one, e.g. as produced by a CSVDecodeExpr failing on a
+// ragged row.
+func IsCSVDecodeError(err error) bool {
+	if ctxErr, ok := err.(ContextErr); ok {
+		_, ok := ctxErr.NextErr().(CSVDecodeError)
+		return ok
+	}
+	_, ok := err.(CSVDecodeError)
+	return ok
+}
+
+// CSVDecodeExpr is `csvDecode(s, header, delimiter)`: parses String s as CSV,
+// producing a relation (a Set of Tuples), one per row. When header is true,
+// the first row supplies the column names and is excluded from the result;
+// otherwise columns are named positionally, "@0", "@1", etc. delimiter
+// selects the single-rune field separator, overriding the default comma. A
+// row with the wrong number of fields produces a catchable CSVDecodeError
+// naming the line, rather than panicking.
+type CSVDecodeExpr struct {
+	ExprScanner
+	s, header, delimiter Expr
+}
+
+// NewCSVDecodeExpr returns a new CSVDecodeExpr.
+func NewCSVDecodeExpr(scanner parser.Scanner, s, header, delimiter Expr) Expr {
+	return &CSVDecodeExpr{ExprScanner{scanner}, s, header, delimiter}
+}
+
+// String returns a string representation of the expression.
+func (e *CSVDecodeExpr) String() string {
+	return fmt.Sprintf("csvDecode(%s, %s, %s)", e.s, e.header, e.delimiter)
+}
+
+// Eval parses s as CSV, returning a CSVDecodeError on a ragged row.
+func (e *CSVDecodeExpr) Eval(local Scope) (Value, error) {
+	sVal, err := e.s.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	sSet, ok := sVal.(Set)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("csvDecode s must be a String, not %T", sVal), e, local)
+	}
+	s, ok := AsString(sSet)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("csvDecode s must be a String, not %T", sVal), e, local)
+	}
+
+	headerVal, err := e.header.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+
+	delimiterVal, err := e.delimiter.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	delimiterSet, ok := delimiterVal.(Set)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("csvDecode delimiter must be a String, not %T", delimiterVal), e, local)
+	}
+	delimiterStr, ok := AsString(delimiterSet)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("csvDecode delimiter must be a String, not %T", delimiterVal), e, local)
+	}
+	delimiterRunes := []rune(delimiterStr.String())
+	if len(delimiterRunes) != 1 {
+		return nil, WrapContext(
+			errors.Errorf("csvDecode delimiter must be a single-rune String, not %q", delimiterStr.String()),
+			e, local)
+	}
+
+	r := csv.NewReader(strings.NewReader(s.String()))
+	r.Comma = delimiterRunes[0]
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, WrapContext(CSVDecodeError{errors.Errorf("csvDecode: %s", err)}, e, local)
+	}
+
+	var names []string
+	if headerVal.IsTrue() {
+		if len(records) == 0 {
+			return None, nil
+		}
+		names, records = records[0], records[1:]
+	}
+
+	tuples := make([]Value, len(records))
+	for i, record := range records {
+		var b TupleBuilder
+		for j, field := range record {
+			name := fmt.Sprintf("@%d", j)
+			if names != nil {
+				name = names[j]
+			}
+			b.Put(name, NewString([]rune(field)))
+		}
+		tuples[i] = b.Finish()
+	}
+
+	return NewSet(tuples...), nil
+}
@@ -0,0 +1,50 @@
+package rel
+
+import (
+	"fmt"
+
+	"github.com/arr-ai/wbnf/parser"
+)
+
+// DebugExpr is `debug(label, body)`: an identity tap that evaluates label
+// and body, reports them to sink, and returns body's value unchanged.
+type DebugExpr struct {
+	ExprScanner
+	label, body Expr
+	sink        func(label string, value Value)
+}
+
+// NewDebugExpr returns a new DebugExpr. sink may be nil, in which case
+// debug is a no-op tap: body is still evaluated and passed through, but
+// nothing is reported.
+func NewDebugExpr(scanner parser.Scanner, label, body Expr, sink func(label string, value Value)) Expr {
+	return &DebugExpr{ExprScanner{scanner}, label, body, sink}
+}
+
+// String returns a string representation of the expression.
+func (e *DebugExpr) String() string {
+	return fmt.Sprintf("debug(%s, %s)", e.label, e.body)
+}
+
+// Eval evaluates label and body, reports them to sink if set, and returns
+// body's value unchanged.
+func (e *DebugExpr) Eval(local Scope) (Value, error) {
+	labelValue, err := e.label.Eval(local)
+	if err != nil {
+		return nil, err
+	}
+	value, err := e.body.Eval(local)
+	if err != nil {
+		return nil, err
+	}
+	if e.sink != nil {
+		label := Repr(labelValue)
+		if set, is := labelValue.(Set); is {
+			if s, is := AsString(set); is {
+				label = s.String()
+			}
+		}
+		e.sink(label, value)
+	}
+	return value, nil
+}
@@ -0,0 +1,86 @@
+package rel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// DedentExpr is `dedent(s)`: s with the common leading whitespace prefix of
+// its non-blank lines stripped from every line. Blank lines (empty, or
+// containing only whitespace) are ignored when computing the common prefix,
+// and are left as-is.
+type DedentExpr struct {
+	ExprScanner
+	s Expr
+}
+
+// NewDedentExpr returns a new DedentExpr.
+func NewDedentExpr(scanner parser.Scanner, s Expr) Expr {
+	return &DedentExpr{ExprScanner{scanner}, s}
+}
+
+// String returns a string representation of the expression.
+func (e *DedentExpr) String() string {
+	return fmt.Sprintf("dedent(%s)", e.s)
+}
+
+// Eval evaluates s and strips its common leading whitespace.
+func (e *DedentExpr) Eval(local Scope) (Value, error) {
+	sVal, err := e.s.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	sSet, ok := sVal.(Set)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("dedent: s must be a String, not %T", sVal), e, local)
+	}
+	s, ok := AsString(sSet)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("dedent: s must be a String, not %T", sVal), e, local)
+	}
+
+	return NewString([]rune(dedent(s.String()))), nil
+}
+
+// dedent strips the common leading whitespace prefix of the non-blank lines
+// of s from every line.
+func dedent(s string) string {
+	lines := strings.Split(s, "\n")
+
+	prefix := ""
+	havePrefix := false
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if !havePrefix {
+			prefix, havePrefix = indent, true
+			continue
+		}
+		prefix = commonPrefix(prefix, indent)
+	}
+
+	if prefix == "" {
+		return s
+	}
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lines[i] = strings.TrimPrefix(line, prefix)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// commonPrefix returns the longest common prefix of a and b.
+func commonPrefix(a, b string) string {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return a[:i]
+}
@@ -0,0 +1,128 @@
+package rel
+
+import (
+	"fmt"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// DeepEqualExpr is `approxEqual(a, b, eps)`: structurally compares a and b,
+// treating Numbers within eps of each other as equal, and recursing through
+// Tuples, Arrays and Sets. Non-numeric leaves (String, Bytes, ...) fall
+// back to exact Equal, since there's no meaningful tolerance for them.
+type DeepEqualExpr struct {
+	ExprScanner
+	a, b, eps Expr
+}
+
+// NewDeepEqualExpr returns a new DeepEqualExpr.
+func NewDeepEqualExpr(scanner parser.Scanner, a, b, eps Expr) Expr {
+	return &DeepEqualExpr{ExprScanner{scanner}, a, b, eps}
+}
+
+// String returns a string representation of the expression.
+func (e *DeepEqualExpr) String() string {
+	return fmt.Sprintf("approxEqual(%s, %s, %s)", e.a, e.b, e.eps)
+}
+
+// Eval evaluates a, b and eps, then compares a and b for structural,
+// tolerance-aware equality.
+func (e *DeepEqualExpr) Eval(local Scope) (Value, error) {
+	a, err := e.a.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	b, err := e.b.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	epsVal, err := e.eps.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	epsNum, ok := epsVal.(Number)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("approxEqual: eps must be a number, not %T", epsVal), e, local)
+	}
+
+	return NewBool(deepApproxEqual(a, b, epsNum.Float64())), nil
+}
+
+// deepApproxEqual compares a and b structurally: Numbers are equal if their
+// difference is at most eps; Tuples are equal if they share the same attr
+// names and each attr's values are deepApproxEqual; Arrays are equal if
+// they're the same length and deepApproxEqual element-wise; other Sets are
+// equal if every element of one has a distinct deepApproxEqual match in
+// the other. Anything else (String, Bytes, ...) falls back to Equal.
+func deepApproxEqual(a, b Value, eps float64) bool {
+	switch av := a.(type) {
+	case Number:
+		bv, ok := b.(Number)
+		if !ok {
+			return false
+		}
+		diff := av.Float64() - bv.Float64()
+		if diff < 0 {
+			diff = -diff
+		}
+		return diff <= eps
+	case Tuple:
+		bv, ok := b.(Tuple)
+		if !ok || !av.Names().Equal(bv.Names()) {
+			return false
+		}
+		for _, name := range av.Names().OrderedNames() {
+			aAttr, _ := av.Get(name)
+			bAttr, _ := bv.Get(name)
+			if !deepApproxEqual(aAttr, bAttr, eps) {
+				return false
+			}
+		}
+		return true
+	case String:
+		bv, ok := b.(String)
+		return ok && av.Equal(bv)
+	case Bytes:
+		bv, ok := b.(Bytes)
+		return ok && av.Equal(bv)
+	case Array:
+		bv, ok := b.(Array)
+		if !ok {
+			return false
+		}
+		aVals, bVals := av.Values(), bv.Values()
+		if len(aVals) != len(bVals) {
+			return false
+		}
+		for i, aVal := range aVals {
+			if !deepApproxEqual(aVal, bVals[i], eps) {
+				return false
+			}
+		}
+		return true
+	case Set:
+		bv, ok := b.(Set)
+		if !ok || av.Count() != bv.Count() {
+			return false
+		}
+		remaining := ValueEnumeratorToSlice(bv.Enumerator())
+		for e := av.Enumerator(); e.MoveNext(); {
+			aElem := e.Current()
+			matched := -1
+			for i, bElem := range remaining {
+				if deepApproxEqual(aElem, bElem, eps) {
+					matched = i
+					break
+				}
+			}
+			if matched == -1 {
+				return false
+			}
+			remaining = append(remaining[:matched], remaining[matched+1:]...)
+		}
+		return true
+	default:
+		return a.Equal(b)
+	}
+}
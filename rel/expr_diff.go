@@ -0,0 +1,150 @@
+package rel
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/arr-ai/wbnf/parser"
+)
+
+// StructuralDiffExpr is `diff(a, b)`: structurally compares a and b,
+// recursing through tuples (by attribute name), arrays (by index) and
+// generic sets (by membership), and returns a Set of Tuples describing the
+// differences.
+// Each diff Tuple has a "path" (an Array of String attribute names/indices
+// from the root to the differing location), a "kind" ("added", "removed" or
+// "changed"), and an "a" and/or "b" holding the differing value(s) — "added"
+// entries have only "b", "removed" entries have only "a", and "changed"
+// entries have both. Equal values produce an empty Set.
+type StructuralDiffExpr struct {
+	ExprScanner
+	a, b Expr
+}
+
+// NewStructuralDiffExpr returns a new StructuralDiffExpr.
+func NewStructuralDiffExpr(scanner parser.Scanner, a, b Expr) Expr {
+	return &StructuralDiffExpr{ExprScanner{scanner}, a, b}
+}
+
+// String returns a string representation of the expression.
+func (e *StructuralDiffExpr) String() string {
+	return fmt.Sprintf("diff(%s, %s)", e.a, e.b)
+}
+
+// Eval evaluates a and b, then returns their structural diff.
+func (e *StructuralDiffExpr) Eval(local Scope) (Value, error) {
+	a, err := e.a.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	b, err := e.b.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+
+	return NewSet(diffValues(a, b, nil)...), nil
+}
+
+func diffValues(a, b Value, path []Value) []Value {
+	if a.Equal(b) {
+		return nil
+	}
+
+	switch av := a.(type) {
+	case Tuple:
+		if bv, ok := b.(Tuple); ok {
+			return diffTuples(av, bv, path)
+		}
+	case Array:
+		if bv, ok := b.(Array); ok {
+			return diffArrays(av, bv, path)
+		}
+	case GenericSet:
+		if bv, ok := b.(GenericSet); ok {
+			return diffSets(av, bv, path)
+		}
+	}
+
+	return []Value{diffEntry(path, "changed", a, b)}
+}
+
+func diffTuples(a, b Tuple, path []Value) []Value {
+	names := a.Names()
+	for _, name := range b.Names().OrderedNames() {
+		names = names.With(name)
+	}
+
+	var diffs []Value
+	for _, name := range names.OrderedNames() {
+		subpath := appendPath(path, name)
+		av, aok := a.Get(name)
+		bv, bok := b.Get(name)
+		switch {
+		case aok && bok:
+			diffs = append(diffs, diffValues(av, bv, subpath)...)
+		case aok:
+			diffs = append(diffs, diffEntry(subpath, "removed", av, nil))
+		default:
+			diffs = append(diffs, diffEntry(subpath, "added", nil, bv))
+		}
+	}
+	return diffs
+}
+
+func diffArrays(a, b Array, path []Value) []Value {
+	av, bv := a.Values(), b.Values()
+
+	n := len(av)
+	if len(bv) > n {
+		n = len(bv)
+	}
+
+	var diffs []Value
+	for i := 0; i < n; i++ {
+		subpath := appendPath(path, strconv.Itoa(i))
+		switch {
+		case i < len(av) && i < len(bv):
+			diffs = append(diffs, diffValues(av[i], bv[i], subpath)...)
+		case i < len(av):
+			diffs = append(diffs, diffEntry(subpath, "removed", av[i], nil))
+		default:
+			diffs = append(diffs, diffEntry(subpath, "added", nil, bv[i]))
+		}
+	}
+	return diffs
+}
+
+func diffSets(a, b GenericSet, path []Value) []Value {
+	var diffs []Value
+
+	for e := a.Enumerator(); e.MoveNext(); {
+		v := e.Current()
+		if !b.Has(v) {
+			diffs = append(diffs, diffEntry(path, "removed", v, nil))
+		}
+	}
+	for e := b.Enumerator(); e.MoveNext(); {
+		v := e.Current()
+		if !a.Has(v) {
+			diffs = append(diffs, diffEntry(path, "added", nil, v))
+		}
+	}
+	return diffs
+}
+
+func appendPath(path []Value, step string) []Value {
+	subpath := make([]Value, len(path), len(path)+1)
+	copy(subpath, path)
+	return append(subpath, NewString([]rune(step)))
+}
+
+func diffEntry(path []Value, kind string, a, b Value) Value {
+	attrs := []Attr{NewAttr("path", NewArray(path...)), NewAttr("kind", NewString([]rune(kind)))}
+	if a != nil {
+		attrs = append(attrs, NewAttr("a", a))
+	}
+	if b != nil {
+		attrs = append(attrs, NewAttr("b", b))
+	}
+	return NewTuple(attrs...)
+}
@@ -15,6 +15,18 @@ func (m MissingAttrError) Error() string {
 	return m.ctxErr.Error()
 }
 
+// IsMissingAttrError reports whether err is a MissingAttrError, or a
+// ContextErr directly wrapping one, e.g. as produced by evaluating a DotExpr
+// against a tuple that lacks the given attr.
+func IsMissingAttrError(err error) bool {
+	if ctxErr, ok := err.(ContextErr); ok {
+		_, ok := ctxErr.NextErr().(MissingAttrError)
+		return ok
+	}
+	_, ok := err.(MissingAttrError)
+	return ok
+}
+
 // DotExpr returns the tuple or set with a single field replaced by an
 // expression.
 type DotExpr struct {
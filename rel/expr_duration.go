@@ -0,0 +1,75 @@
+package rel
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// DurationExpr implements `duration(s)`, `addDuration(t, d)` and
+// `timeDiff(a, b)`: durations are a Number of nanoseconds, as produced by
+// parsing a Go duration string (e.g. "1h30m", "500ms"); times are the Number
+// of seconds since the Unix epoch, as produced by `now()`. Since durations
+// and times are both plain Numbers, comparing two durations (or two times)
+// needs no dedicated operator; the usual comparison operators apply.
+type DurationExpr struct {
+	ExprScanner
+	class string
+	a, b  Expr
+}
+
+// NewDurationExpr returns a new DurationExpr. b is unused (and must be nil)
+// when class is "duration".
+func NewDurationExpr(scanner parser.Scanner, class string, a, b Expr) Expr {
+	return &DurationExpr{ExprScanner{scanner}, class, a, b}
+}
+
+// String returns a string representation of the expression.
+func (e *DurationExpr) String() string {
+	switch e.class {
+	case "addDuration":
+		return fmt.Sprintf("addDuration(%s, %s)", e.a, e.b)
+	case "timeDiff":
+		return fmt.Sprintf("timeDiff(%s, %s)", e.a, e.b)
+	default:
+		return fmt.Sprintf("duration(%s)", e.a)
+	}
+}
+
+// Eval evaluates the expression per e.class.
+func (e *DurationExpr) Eval(local Scope) (Value, error) {
+	switch e.class {
+	case "addDuration":
+		t, err := evalNumber(e.a, local, "addDuration")
+		if err != nil {
+			return nil, WrapContext(err, e, local)
+		}
+		d, err := evalNumber(e.b, local, "addDuration")
+		if err != nil {
+			return nil, WrapContext(err, e, local)
+		}
+		return NewNumber(t + d/1e9), nil
+	case "timeDiff":
+		a, err := evalNumber(e.a, local, "timeDiff")
+		if err != nil {
+			return nil, WrapContext(err, e, local)
+		}
+		b, err := evalNumber(e.b, local, "timeDiff")
+		if err != nil {
+			return nil, WrapContext(err, e, local)
+		}
+		return NewNumber((a - b) * 1e9), nil
+	default:
+		s, err := evalString(e.a, local, "duration")
+		if err != nil {
+			return nil, WrapContext(err, e, local)
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return nil, WrapContext(errors.Errorf("duration: %s", err), e, local)
+		}
+		return NewNumber(float64(d.Nanoseconds())), nil
+	}
+}
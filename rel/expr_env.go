@@ -0,0 +1,80 @@
+package rel
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// EnvExpr is `env(name, default)`: looks up the environment variable name
+// via lookup, falling back to default if set when the variable is missing.
+// A missing variable with no default produces a catchable EnvMissingError.
+type EnvExpr struct {
+	ExprScanner
+	name, def Expr
+	lookup    func(name string) (string, bool)
+}
+
+// NewEnvExpr returns a new EnvExpr. def may be nil if no default was given.
+// lookup defaults to os.LookupEnv when nil.
+func NewEnvExpr(scanner parser.Scanner, name, def Expr, lookup func(name string) (string, bool)) Expr {
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+	return &EnvExpr{ExprScanner{scanner}, name, def, lookup}
+}
+
+// String returns a string representation of the expression.
+func (e *EnvExpr) String() string {
+	if e.def == nil {
+		return fmt.Sprintf("env(%s)", e.name)
+	}
+	return fmt.Sprintf("env(%s, %s)", e.name, e.def)
+}
+
+// Eval looks up the named environment variable, falling back to e.def, or
+// failing with a catchable EnvMissingError if e.def is nil.
+func (e *EnvExpr) Eval(local Scope) (Value, error) {
+	name, err := evalString(e.name, local, "env")
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+
+	if value, ok := e.lookup(name); ok {
+		return NewString([]rune(value)), nil
+	}
+
+	if e.def != nil {
+		value, err := e.def.Eval(local)
+		if err != nil {
+			return nil, WrapContext(err, e, local)
+		}
+		return value, nil
+	}
+
+	return nil, WrapContext(EnvMissingError{errors.Errorf("env: no such variable: %s", name)}, e, local)
+}
+
+// EnvMissingError wraps a missing-environment-variable failure from EnvExpr
+// so it can be caught with `try env(...) catch env handler`.
+type EnvMissingError struct {
+	ctxErr error
+}
+
+func (p EnvMissingError) Error() string {
+	return p.ctxErr.Error()
+}
+
+// IsEnvMissingError reports whether err is an EnvMissingError, or a
+// ContextErr directly wrapping one, e.g. as produced by EnvExpr failing to
+// find a variable with no default.
+func IsEnvMissingError(err error) bool {
+	if ctxErr, ok := err.(ContextErr); ok {
+		_, ok := ctxErr.NextErr().(EnvMissingError)
+		return ok
+	}
+	_, ok := err.(EnvMissingError)
+	return ok
+}
@@ -0,0 +1,77 @@
+package rel
+
+import (
+	"fmt"
+
+	"github.com/arr-ai/wbnf/parser"
+)
+
+// FindExpr is `a find(pred)` or `a find(pred, fallback)`: returns the first
+// element of Set a satisfying pred, short-circuiting as soon as one is
+// found. If a is an Array, "first" means first by index; if a is any other
+// kind of Set, there is no defined element order, so "first" means whatever
+// element its Enumerator happens to visit first — a deterministic result
+// for a given a, but not a meaningful one, so callers wanting a specific
+// element from an unordered Set should sort it into an Array first. If no
+// element satisfies pred, fallback is evaluated and returned, or None if
+// fallback was not supplied.
+type FindExpr struct {
+	ExprScanner
+	a, pred, fallback Expr
+}
+
+// NewFindExpr returns a new FindExpr. fallback may be nil, in which case
+// find evaluates to None when no element of a satisfies pred.
+func NewFindExpr(scanner parser.Scanner, a, pred, fallback Expr) Expr {
+	return &FindExpr{ExprScanner{scanner}, a, ExprAsFunction(pred), fallback}
+}
+
+// String returns a string representation of the expression.
+func (e *FindExpr) String() string {
+	if e.fallback == nil {
+		return fmt.Sprintf("%s find(%s)", e.a, e.pred)
+	}
+	return fmt.Sprintf("%s find(%s, %s)", e.a, e.pred, e.fallback)
+}
+
+// Eval evaluates a and pred, then returns the first element of a satisfying
+// pred, or the evaluated fallback (or None) if none do.
+func (e *FindExpr) Eval(local Scope) (Value, error) {
+	aVal, err := e.a.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	x, ok := aVal.(Set)
+	if !ok {
+		return nil, WrapContext(fmt.Errorf("find lhs must be a Set, not %T", aVal), e, local)
+	}
+
+	predVal, err := e.pred.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	p, ok := predVal.(Closure)
+	if !ok {
+		return nil, WrapContext(fmt.Errorf("find pred must be a Fn, not %T", predVal), e, local)
+	}
+
+	for en := x.Enumerator(); en.MoveNext(); {
+		v := en.Current()
+		r, err := SetCall(p, v)
+		if err != nil {
+			return nil, WrapContext(err, e, local)
+		}
+		if r.IsTrue() {
+			return v, nil
+		}
+	}
+
+	if e.fallback == nil {
+		return None, nil
+	}
+	fallback, err := e.fallback.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	return fallback, nil
+}
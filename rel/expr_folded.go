@@ -0,0 +1,30 @@
+package rel
+
+import "github.com/arr-ai/wbnf/parser"
+
+// FoldedExpr wraps an expr whose value was computed once at compile time
+// (e.g. a comparison over two literals). It evaluates to that cached value
+// without re-running original, but keeps original's String()/Source() so
+// the compiled expr still prints and locates like the source it came from.
+type FoldedExpr struct {
+	original Expr
+	value    Value
+}
+
+// NewFoldedExpr returns a FoldedExpr that evaluates to value instead of
+// evaluating original.
+func NewFoldedExpr(original Expr, value Value) FoldedExpr {
+	return FoldedExpr{original: original, value: value}
+}
+
+func (e FoldedExpr) String() string {
+	return e.original.String()
+}
+
+func (e FoldedExpr) Eval(_ Scope) (Value, error) {
+	return e.value, nil
+}
+
+func (e FoldedExpr) Source() parser.Scanner {
+	return e.original.Source()
+}
@@ -0,0 +1,112 @@
+package rel
+
+import (
+	"fmt"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// GCDExpr is `gcd(a, b)`: the greatest common divisor of integral numbers a
+// and b. gcd(0, 0) is defined as 0.
+type GCDExpr struct {
+	ExprScanner
+	a, b Expr
+}
+
+// NewGCDExpr returns a new GCDExpr.
+func NewGCDExpr(scanner parser.Scanner, a, b Expr) Expr {
+	return &GCDExpr{ExprScanner{scanner}, a, b}
+}
+
+// String returns a string representation of the expression.
+func (e *GCDExpr) String() string {
+	return fmt.Sprintf("gcd(%s, %s)", e.a, e.b)
+}
+
+// Eval evaluates a and b, then returns their greatest common divisor.
+func (e *GCDExpr) Eval(local Scope) (Value, error) {
+	a, b, err := evalIntPair(e.a, e.b, local, "gcd")
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	return NewNumber(float64(gcd(a, b))), nil
+}
+
+// LCMExpr is `lcm(a, b)`: the least common multiple of integral numbers a
+// and b. lcm(0, 0) is defined as 0.
+type LCMExpr struct {
+	ExprScanner
+	a, b Expr
+}
+
+// NewLCMExpr returns a new LCMExpr.
+func NewLCMExpr(scanner parser.Scanner, a, b Expr) Expr {
+	return &LCMExpr{ExprScanner{scanner}, a, b}
+}
+
+// String returns a string representation of the expression.
+func (e *LCMExpr) String() string {
+	return fmt.Sprintf("lcm(%s, %s)", e.a, e.b)
+}
+
+// Eval evaluates a and b, then returns their least common multiple.
+func (e *LCMExpr) Eval(local Scope) (Value, error) {
+	a, b, err := evalIntPair(e.a, e.b, local, "lcm")
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	if a == 0 && b == 0 {
+		return NewNumber(0), nil
+	}
+	g := gcd(a, b)
+	return NewNumber(float64(abs(a/g) * abs(b))), nil
+}
+
+// evalIntPair evaluates a and b, returning them as ints or erroring with a
+// message naming op if either isn't a whole number.
+func evalIntPair(aExpr, bExpr Expr, local Scope, op string) (int, int, error) {
+	aVal, err := aExpr.Eval(local)
+	if err != nil {
+		return 0, 0, err
+	}
+	bVal, err := bExpr.Eval(local)
+	if err != nil {
+		return 0, 0, err
+	}
+	a, ok := asInt(aVal)
+	if !ok {
+		return 0, 0, errors.Errorf("%s: a must be an integral number, not %v", op, aVal)
+	}
+	b, ok := asInt(bVal)
+	if !ok {
+		return 0, 0, errors.Errorf("%s: b must be an integral number, not %v", op, bVal)
+	}
+	return a, b, nil
+}
+
+// asInt extracts a whole-number int from v, if v is a Number holding one.
+func asInt(v Value) (int, bool) {
+	n, ok := v.(Number)
+	if !ok {
+		return 0, false
+	}
+	return n.Int()
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// gcd returns the greatest common divisor of a and b, per Euclid's
+// algorithm. gcd(0, 0) is 0.
+func gcd(a, b int) int {
+	a, b = abs(a), abs(b)
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
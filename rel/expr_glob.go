@@ -0,0 +1,56 @@
+package rel
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+)
+
+// GlobExpr is `glob(pattern)`: lists file paths matching pattern (a
+// filepath.Match-style pattern) against fs, returning a sorted Array of
+// String paths.
+type GlobExpr struct {
+	ExprScanner
+	pattern Expr
+	fs      afero.Fs
+}
+
+// NewGlobExpr returns a new GlobExpr. fs defaults to the OS filesystem when
+// nil.
+func NewGlobExpr(scanner parser.Scanner, pattern Expr, fs afero.Fs) Expr {
+	if fs == nil {
+		fs = afero.NewOsFs()
+	}
+	return &GlobExpr{ExprScanner{scanner}, pattern, fs}
+}
+
+// String returns a string representation of the expression.
+func (e *GlobExpr) String() string {
+	return fmt.Sprintf("glob(%s)", e.pattern)
+}
+
+// Eval evaluates pattern, then lists matching paths from e.fs as a sorted
+// Array of Strings.
+func (e *GlobExpr) Eval(local Scope) (Value, error) {
+	pattern, err := evalString(e.pattern, local, "glob")
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+
+	matches, err := afero.Glob(e.fs, pattern)
+	if err != nil {
+		return nil, WrapContext(errors.Errorf("glob: %s", err), e, local)
+	}
+
+	sort.Strings(matches)
+
+	values := make([]Value, len(matches))
+	for i, m := range matches {
+		values[i] = NewString([]rune(m))
+	}
+
+	return NewArray(values...), nil
+}
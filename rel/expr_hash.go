@@ -0,0 +1,39 @@
+package rel
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/arr-ai/wbnf/parser"
+)
+
+// HashExpr is `hash(value)`: a stable hex-string hash of value, computed
+// structurally so equal values hash equal regardless of construction order
+// (e.g. two Sets built by inserting the same elements in different orders).
+// Repr already canonicalizes Set/Tuple ordering for printing, so hashing its
+// output gives a hash that's both order-independent and stable across runs.
+type HashExpr struct {
+	ExprScanner
+	value Expr
+}
+
+// NewHashExpr returns a new HashExpr.
+func NewHashExpr(scanner parser.Scanner, value Expr) Expr {
+	return &HashExpr{ExprScanner{scanner}, value}
+}
+
+// String returns a string representation of the expression.
+func (e *HashExpr) String() string {
+	return fmt.Sprintf("hash(%s)", e.value)
+}
+
+// Eval evaluates the hash.
+func (e *HashExpr) Eval(local Scope) (Value, error) {
+	value, err := e.value.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	sum := sha256.Sum256([]byte(Repr(value)))
+	return NewString([]rune(hex.EncodeToString(sum[:]))), nil
+}
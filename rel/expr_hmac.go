@@ -0,0 +1,63 @@
+package rel
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// HmacExpr is `hmacSha256(key, message)`: the hex-string HMAC-SHA256 of
+// message under key. key and message may each be a Bytes value or a String
+// (taken as its UTF-8 encoding).
+type HmacExpr struct {
+	ExprScanner
+	key, message Expr
+}
+
+// NewHmacExpr returns a new HmacExpr.
+func NewHmacExpr(scanner parser.Scanner, key, message Expr) Expr {
+	return &HmacExpr{ExprScanner{scanner}, key, message}
+}
+
+// String returns a string representation of the expression.
+func (e *HmacExpr) String() string {
+	return fmt.Sprintf("hmacSha256(%s, %s)", e.key, e.message)
+}
+
+// Eval evaluates key and message and computes their HMAC-SHA256.
+func (e *HmacExpr) Eval(local Scope) (Value, error) {
+	key, err := e.evalBytesOrString(e.key, "key", local)
+	if err != nil {
+		return nil, err
+	}
+	message, err := e.evalBytesOrString(e.message, "message", local)
+	if err != nil {
+		return nil, err
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(message)
+	return NewString([]rune(hex.EncodeToString(mac.Sum(nil)))), nil
+}
+
+// evalBytesOrString evaluates expr and returns its raw bytes, requiring it
+// be a Bytes value or a String.
+func (e *HmacExpr) evalBytesOrString(expr Expr, name string, local Scope) ([]byte, error) {
+	val, err := expr.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	set, ok := val.(Set)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("hmacSha256: %s must be Bytes or a String, not %T", name, val), e, local)
+	}
+	b, ok := asBytesOrString(set)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("hmacSha256: %s must be Bytes or a String, not %T", name, val), e, local)
+	}
+	return b, nil
+}
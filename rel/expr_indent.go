@@ -0,0 +1,80 @@
+package rel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// IndentExpr is `indent(s, prefix)` or `indent(s, prefix, all)`: s with
+// prefix prepended to each line. By default (all omitted or false), empty
+// lines are left alone so prefix's trailing whitespace, if any, isn't left
+// dangling; passing all=true prepends prefix to every line, including empty
+// ones. This is the inverse of dedent.
+type IndentExpr struct {
+	ExprScanner
+	s, prefix, all Expr
+}
+
+// NewIndentExpr returns a new IndentExpr. all may be nil, meaning false.
+func NewIndentExpr(scanner parser.Scanner, s, prefix, all Expr) Expr {
+	return &IndentExpr{ExprScanner{scanner}, s, prefix, all}
+}
+
+// String returns a string representation of the expression.
+func (e *IndentExpr) String() string {
+	if e.all == nil {
+		return fmt.Sprintf("indent(%s, %s)", e.s, e.prefix)
+	}
+	return fmt.Sprintf("indent(%s, %s, %s)", e.s, e.prefix, e.all)
+}
+
+// Eval evaluates s and prefix and prepends prefix to each (or each
+// non-empty) line of s.
+func (e *IndentExpr) Eval(local Scope) (Value, error) {
+	sVal, err := e.s.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	sSet, ok := sVal.(Set)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("indent: s must be a String, not %T", sVal), e, local)
+	}
+	s, ok := AsString(sSet)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("indent: s must be a String, not %T", sVal), e, local)
+	}
+
+	prefixVal, err := e.prefix.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	prefixSet, ok := prefixVal.(Set)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("indent: prefix must be a String, not %T", prefixVal), e, local)
+	}
+	prefix, ok := AsString(prefixSet)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("indent: prefix must be a String, not %T", prefixVal), e, local)
+	}
+
+	all := false
+	if e.all != nil {
+		allVal, err := e.all.Eval(local)
+		if err != nil {
+			return nil, WrapContext(err, e, local)
+		}
+		all = allVal.IsTrue()
+	}
+
+	lines := strings.Split(s.String(), "\n")
+	for i, line := range lines {
+		if line == "" && !all {
+			continue
+		}
+		lines[i] = prefix.String() + line
+	}
+	return NewString([]rune(strings.Join(lines, "\n"))), nil
+}
@@ -0,0 +1,92 @@
+package rel
+
+import (
+	"fmt"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// IndexOfExpr is `indexOf(a, v)`: for an Array a, the index of the first
+// element equal to v; for a String a, the rune index of the first
+// occurrence of v (also a String) as a substring. Returns -1, not None, when
+// v is absent, so the result is always a Number and arithmetic/comparison
+// on it (e.g. `indexOf(a, v) >= 0`) doesn't need a None check first.
+type IndexOfExpr struct {
+	ExprScanner
+	a, v Expr
+}
+
+// NewIndexOfExpr returns a new IndexOfExpr.
+func NewIndexOfExpr(scanner parser.Scanner, a, v Expr) Expr {
+	return &IndexOfExpr{ExprScanner{scanner}, a, v}
+}
+
+// String returns a string representation of the expression.
+func (e *IndexOfExpr) String() string {
+	return fmt.Sprintf("indexOf(%s, %s)", e.a, e.v)
+}
+
+// Eval evaluates a and v, then returns the index of v's first occurrence in
+// a, or -1 if absent.
+func (e *IndexOfExpr) Eval(local Scope) (Value, error) {
+	aVal, err := e.a.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	vVal, err := e.v.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+
+	if aSet, ok := aVal.(Set); ok {
+		if aStr, ok := AsString(aSet); ok {
+			vSet, ok := vVal.(Set)
+			if !ok {
+				return nil, WrapContext(errors.Errorf("indexOf: a String a can only search for a String, not %T", vVal), e, local)
+			}
+			vStr, ok := AsString(vSet)
+			if !ok {
+				return nil, WrapContext(errors.Errorf("indexOf: a String a can only search for a String, not %T", vVal), e, local)
+			}
+			return NewNumber(float64(indexOfRunes([]rune(aStr.String()), []rune(vStr.String())))), nil
+		}
+	}
+
+	if aArr, ok := aVal.(Array); ok {
+		for i, elt := range aArr.Values() {
+			if elt.Equal(vVal) {
+				return NewNumber(float64(i)), nil
+			}
+		}
+		return NewNumber(-1), nil
+	}
+
+	return nil, WrapContext(errors.Errorf("indexOf: a must be an Array or String, not %T", aVal), e, local)
+}
+
+// indexOfRunes returns the index of the first occurrence of sub within s, or
+// -1 if sub does not occur (an empty sub always occurs at index 0).
+func indexOfRunes(s, sub []rune) int {
+	if len(sub) == 0 {
+		return 0
+	}
+	for i := 0; i+len(sub) <= len(s); i++ {
+		if runesEqual(s[i:i+len(sub)], sub) {
+			return i
+		}
+	}
+	return -1
+}
+
+func runesEqual(a, b []rune) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
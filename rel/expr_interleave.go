@@ -0,0 +1,75 @@
+package rel
+
+import (
+	"bytes"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// InterleaveExpr is `interleave(a, b, ...)`: merges any number of Arrays
+// round-robin (a[0], b[0], ..., a[1], b[1], ..., ...), continuing with the
+// remaining elements of longer arrays once shorter ones are exhausted. An
+// empty array contributes nothing and is effectively skipped.
+type InterleaveExpr struct {
+	ExprScanner
+	arrs []Expr
+}
+
+// NewInterleaveExpr returns a new InterleaveExpr.
+func NewInterleaveExpr(scanner parser.Scanner, arrs ...Expr) Expr {
+	return &InterleaveExpr{ExprScanner{scanner}, arrs}
+}
+
+// String returns a string representation of the expression.
+func (e *InterleaveExpr) String() string {
+	var b bytes.Buffer
+	b.WriteString("interleave(")
+	for i, arr := range e.arrs {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(arr.String())
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+// Eval evaluates each arg to an Array, then merges them round-robin.
+func (e *InterleaveExpr) Eval(local Scope) (Value, error) {
+	arrs := make([][]Value, len(e.arrs))
+	maxLen := 0
+	for i, expr := range e.arrs {
+		v, err := expr.Eval(local)
+		if err != nil {
+			return nil, WrapContext(err, e, local)
+		}
+		var values []Value
+		switch v := v.(type) {
+		case Array:
+			values = v.Values()
+		case Set:
+			// The literal `[]` is indistinguishable from an empty Set, so
+			// accept one here as a degenerate empty array to interleave.
+			if v.Count() != 0 {
+				return nil, WrapContext(errors.Errorf("interleave: argument must be an Array, not %T", v), e, local)
+			}
+		default:
+			return nil, WrapContext(errors.Errorf("interleave: argument must be an Array, not %T", v), e, local)
+		}
+		arrs[i] = values
+		if len(values) > maxLen {
+			maxLen = len(values)
+		}
+	}
+
+	var result []Value
+	for i := 0; i < maxLen; i++ {
+		for _, arr := range arrs {
+			if i < len(arr) {
+				result = append(result, arr[i])
+			}
+		}
+	}
+	return NewArray(result...), nil
+}
@@ -0,0 +1,188 @@
+package rel
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// JSONDecodeError wraps a malformed-input failure from a JSONDecodeExpr so it
+// can be caught with `try jsonDecode(...) catch jsonDecode handler`.
+type JSONDecodeError struct {
+	ctxErr error
+}
+
+func (j JSONDecodeError) Error() string {
+	return j.ctxErr.Error()
+}
+
+// IsJSONDecodeError reports whether err is a JSONDecodeError, or a
+// ContextErr directly wrapping one, e.g. as produced by a JSONDecodeExpr
+// failing to parse its input.
+func IsJSONDecodeError(err error) bool {
+	if ctxErr, ok := err.(ContextErr); ok {
+		_, ok := ctxErr.NextErr().(JSONDecodeError)
+		return ok
+	}
+	_, ok := err.(JSONDecodeError)
+	return ok
+}
+
+// JSONDecodeExpr is `jsonDecode(s)`: parses String s as JSON, mapping objects
+// to tuples (keyed by the JSON key), arrays to arrays, null to None, and
+// numbers/strings/bools to their natural arrai values. Malformed input
+// produces a catchable JSONDecodeError naming the byte offset in s, rather
+// than panicking.
+type JSONDecodeExpr struct {
+	ExprScanner
+	s Expr
+}
+
+// NewJSONDecodeExpr returns a new JSONDecodeExpr.
+func NewJSONDecodeExpr(scanner parser.Scanner, s Expr) Expr {
+	return &JSONDecodeExpr{ExprScanner{scanner}, s}
+}
+
+// String returns a string representation of the expression.
+func (e *JSONDecodeExpr) String() string {
+	return fmt.Sprintf("jsonDecode(%s)", e.s)
+}
+
+// Eval parses s as JSON, returning a JSONDecodeError on failure.
+func (e *JSONDecodeExpr) Eval(local Scope) (Value, error) {
+	sVal, err := e.s.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	sSet, ok := sVal.(Set)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("jsonDecode s must be a String, not %T", sVal), e, local)
+	}
+	s, ok := AsString(sSet)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("jsonDecode s must be a String, not %T", sVal), e, local)
+	}
+
+	var data interface{}
+	if err := json.Unmarshal([]byte(s.String()), &data); err != nil {
+		if syntaxErr, ok := err.(*json.SyntaxError); ok {
+			return nil, WrapContext(
+				JSONDecodeError{errors.Errorf("jsonDecode: %s (at offset %d)", syntaxErr, syntaxErr.Offset)},
+				e, local)
+		}
+		return nil, WrapContext(JSONDecodeError{errors.Errorf("jsonDecode: %s", err)}, e, local)
+	}
+
+	return jsonValueToArrai(data), nil
+}
+
+func jsonValueToArrai(data interface{}) Value {
+	switch x := data.(type) {
+	case nil:
+		return None
+	case bool:
+		if x {
+			return True
+		}
+		return False
+	case float64:
+		return NewNumber(x)
+	case string:
+		return NewString([]rune(x))
+	case []interface{}:
+		values := make([]Value, len(x))
+		for i, item := range x {
+			values[i] = jsonValueToArrai(item)
+		}
+		return NewArray(values...)
+	case map[string]interface{}:
+		var b TupleBuilder
+		for name, item := range x {
+			b.Put(name, jsonValueToArrai(item))
+		}
+		return b.Finish()
+	default:
+		panic(fmt.Sprintf("jsonDecode: unrecognised JSON value: %v (%[1]T)", data))
+	}
+}
+
+// JSONEncodeExpr is `jsonEncode(value)`: the inverse of JSONDecodeExpr,
+// rendering an arrai value (as produced by JSONDecodeExpr, or constructed
+// directly) as a JSON string.
+type JSONEncodeExpr struct {
+	ExprScanner
+	value Expr
+}
+
+// NewJSONEncodeExpr returns a new JSONEncodeExpr.
+func NewJSONEncodeExpr(scanner parser.Scanner, value Expr) Expr {
+	return &JSONEncodeExpr{ExprScanner{scanner}, value}
+}
+
+// String returns a string representation of the expression.
+func (e *JSONEncodeExpr) String() string {
+	return fmt.Sprintf("jsonEncode(%s)", e.value)
+}
+
+// Eval renders value as a JSON string.
+func (e *JSONEncodeExpr) Eval(local Scope) (Value, error) {
+	value, err := e.value.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+
+	data, err := arraiToJSONValue(value)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, WrapContext(errors.Errorf("jsonEncode: %s", err), e, local)
+	}
+
+	return NewString([]rune(string(encoded))), nil
+}
+
+func arraiToJSONValue(value Value) (interface{}, error) {
+	switch x := value.(type) {
+	case Number:
+		return x.Export(), nil
+	case String:
+		return x.String(), nil
+	case Array:
+		values := x.Values()
+		result := make([]interface{}, len(values))
+		for i, v := range values {
+			enc, err := arraiToJSONValue(v)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = enc
+		}
+		return result, nil
+	case Tuple:
+		result := make(map[string]interface{}, x.Count())
+		for e := x.Enumerator(); e.MoveNext(); {
+			name, v := e.Current()
+			enc, err := arraiToJSONValue(v)
+			if err != nil {
+				return nil, err
+			}
+			result[name] = enc
+		}
+		return result, nil
+	case GenericSet:
+		if x.Equal(True) {
+			return true, nil
+		}
+		if x.Equal(None) {
+			return nil, nil
+		}
+		return nil, errors.Errorf("jsonEncode: cannot encode set value: %s", x)
+	default:
+		return nil, errors.Errorf("jsonEncode: cannot encode value: %T", value)
+	}
+}
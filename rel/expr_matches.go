@@ -0,0 +1,36 @@
+package rel
+
+import (
+	"fmt"
+
+	"github.com/arr-ai/wbnf/parser"
+)
+
+// MatchesExpr is `value ~ pattern`: true iff pattern structurally matches
+// value. Any bindings the pattern would otherwise introduce are discarded;
+// this is a test, not a destructuring let.
+type MatchesExpr struct {
+	ExprScanner
+	value   Expr
+	pattern Pattern
+}
+
+// NewMatchesExpr returns a new MatchesExpr.
+func NewMatchesExpr(scanner parser.Scanner, value Expr, pattern Pattern) Expr {
+	return &MatchesExpr{ExprScanner{scanner}, value, pattern}
+}
+
+// String returns a string representation of the expression.
+func (e *MatchesExpr) String() string {
+	return fmt.Sprintf("(%s ~ %s)", e.value, e.pattern)
+}
+
+// Eval returns true iff e.pattern matches e.value.
+func (e *MatchesExpr) Eval(local Scope) (Value, error) {
+	val, err := e.value.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	_, err = e.pattern.Bind(local, val)
+	return NewBool(err == nil), nil
+}
@@ -0,0 +1,49 @@
+package rel
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/arr-ai/wbnf/parser"
+)
+
+// MemoGraphExpr is `memo(body)`: evaluates body the first time this
+// compiled node is reached, and returns the cached result on every
+// subsequent Eval, however many times the surrounding evaluation reaches
+// it again (e.g. via recursion, a loop, or multiple branches that all
+// hold a reference to the same memo node). Unlike FoldedExpr, the value
+// is computed lazily at runtime rather than baked in at compile time, so
+// it can depend on local. It's meant for a pure, expensive sub-expression
+// shared by reference; if body's result actually depends on the calling
+// scope, the scope of the first call wins and later calls silently reuse
+// its result.
+type MemoGraphExpr struct {
+	ExprScanner
+	body Expr
+
+	once  sync.Once
+	value Value
+	err   error
+}
+
+// NewMemoGraphExpr returns a new MemoGraphExpr.
+func NewMemoGraphExpr(scanner parser.Scanner, body Expr) Expr {
+	return &MemoGraphExpr{ExprScanner: ExprScanner{scanner}, body: body}
+}
+
+// String returns a string representation of the expression.
+func (e *MemoGraphExpr) String() string {
+	return fmt.Sprintf("memo(%s)", e.body)
+}
+
+// Eval evaluates body the first time it's called, caching the result (or
+// error) for every later call.
+func (e *MemoGraphExpr) Eval(local Scope) (Value, error) {
+	e.once.Do(func() {
+		e.value, e.err = e.body.Eval(local)
+	})
+	if e.err != nil {
+		return nil, WrapContext(e.err, e, local)
+	}
+	return e.value, nil
+}
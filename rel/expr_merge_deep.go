@@ -0,0 +1,67 @@
+package rel
+
+import (
+	"fmt"
+
+	"github.com/arr-ai/wbnf/parser"
+)
+
+// MergeDeepExpr is `mergeDeep(a, b)`: recursively merges a and b, like the
+// `with` binop but descending into nested tuples instead of replacing them
+// wholesale. For each attribute name present in either tuple: if present in
+// both and both values are tuples, they are merged recursively; otherwise
+// b's value wins (including non-tuple conflicts).
+type MergeDeepExpr struct {
+	ExprScanner
+	a, b Expr
+}
+
+// NewMergeDeepExpr returns a new MergeDeepExpr.
+func NewMergeDeepExpr(scanner parser.Scanner, a, b Expr) Expr {
+	return &MergeDeepExpr{ExprScanner{scanner}, a, b}
+}
+
+// String returns a string representation of the expression.
+func (e *MergeDeepExpr) String() string {
+	return fmt.Sprintf("mergeDeep(%s, %s)", e.a, e.b)
+}
+
+// Eval evaluates a and b, then returns their recursive merge.
+func (e *MergeDeepExpr) Eval(local Scope) (Value, error) {
+	a, err := e.a.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	b, err := e.b.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+
+	return mergeDeep(a, b), nil
+}
+
+func mergeDeep(a, b Value) Value {
+	ta, aok := a.(Tuple)
+	tb, bok := b.(Tuple)
+	if !aok || !bok {
+		return b
+	}
+
+	names := ta.Names()
+	for _, name := range tb.Names().OrderedNames() {
+		names = names.With(name)
+	}
+
+	merged := ta
+	for _, name := range names.OrderedNames() {
+		av, aHas := ta.Get(name)
+		bv, bHas := tb.Get(name)
+		switch {
+		case aHas && bHas:
+			merged = merged.With(name, mergeDeep(av, bv))
+		case bHas:
+			merged = merged.With(name, bv)
+		}
+	}
+	return merged
+}
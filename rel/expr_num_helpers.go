@@ -0,0 +1,106 @@
+package rel
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// AbsExpr is `abs(x)`: the absolute value of number x.
+type AbsExpr struct {
+	ExprScanner
+	x Expr
+}
+
+// NewAbsExpr returns a new AbsExpr.
+func NewAbsExpr(scanner parser.Scanner, x Expr) Expr {
+	return &AbsExpr{ExprScanner{scanner}, x}
+}
+
+// String returns a string representation of the expression.
+func (e *AbsExpr) String() string {
+	return fmt.Sprintf("abs(%s)", e.x)
+}
+
+// Eval evaluates x, then returns its absolute value.
+func (e *AbsExpr) Eval(local Scope) (Value, error) {
+	x, err := evalNumber(e.x, local, "abs")
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	return NewNumber(math.Abs(x)), nil
+}
+
+// SignExpr is `sign(x)`: -1, 0 or 1 according to whether number x is
+// negative, zero or positive.
+type SignExpr struct {
+	ExprScanner
+	x Expr
+}
+
+// NewSignExpr returns a new SignExpr.
+func NewSignExpr(scanner parser.Scanner, x Expr) Expr {
+	return &SignExpr{ExprScanner{scanner}, x}
+}
+
+// String returns a string representation of the expression.
+func (e *SignExpr) String() string {
+	return fmt.Sprintf("sign(%s)", e.x)
+}
+
+// Eval evaluates x, then returns its sign as -1, 0 or 1.
+func (e *SignExpr) Eval(local Scope) (Value, error) {
+	x, err := evalNumber(e.x, local, "sign")
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	switch {
+	case x < 0:
+		return NewNumber(-1), nil
+	case x > 0:
+		return NewNumber(1), nil
+	default:
+		return NewNumber(0), nil
+	}
+}
+
+// Pow10Expr is `pow10(x)`: 10 raised to the power of number x.
+type Pow10Expr struct {
+	ExprScanner
+	x Expr
+}
+
+// NewPow10Expr returns a new Pow10Expr.
+func NewPow10Expr(scanner parser.Scanner, x Expr) Expr {
+	return &Pow10Expr{ExprScanner{scanner}, x}
+}
+
+// String returns a string representation of the expression.
+func (e *Pow10Expr) String() string {
+	return fmt.Sprintf("pow10(%s)", e.x)
+}
+
+// Eval evaluates x, then returns 10**x.
+func (e *Pow10Expr) Eval(local Scope) (Value, error) {
+	x, err := evalNumber(e.x, local, "pow10")
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	return NewNumber(math.Pow(10, x)), nil
+}
+
+// evalNumber evaluates expr and returns it as a float64, erroring with a
+// message naming op if it isn't a Number.
+func evalNumber(expr Expr, local Scope, op string) (float64, error) {
+	v, err := expr.Eval(local)
+	if err != nil {
+		return 0, err
+	}
+	n, ok := v.(Number)
+	if !ok {
+		return 0, errors.Errorf("%s: x must be a number, not %T", op, v)
+	}
+	return float64(n), nil
+}
@@ -7,14 +7,21 @@ import (
 	"github.com/go-errors/errors"
 )
 
-// OffsetExpr is an expression which offsets the provided array by the
-// provided offset
+// OffsetExpr is `n \ s`: an Array, Bytes or String whose indices are shifted
+// by n from s's own offset, without touching s's values or their order. n
+// may be negative, which shifts indices down (e.g. an Array with offset 0
+// offset by -2 has its first element at index -2). Offsetting by 0 is a
+// no-op. The offset is part of the value: an Array offset by a non-zero n is
+// not Equal to s. Concatenating (++, see Concatenate) leaves the lhs's
+// elements at their own indices and places the rhs's elements at the lhs's
+// count plus their own indices, so an offset on either operand carries into
+// the result, e.g. (5 \ [1, 2, 3]) ++ [9, 9] is 3 \ [9, 9, 1, 2, 3].
 type OffsetExpr struct {
 	ExprScanner
 	offset, array Expr
 }
 
-// NewOffsetExpr returns a new OffsetExpr
+// NewOffsetExpr returns a new OffsetExpr representing s offset by n.
 func NewOffsetExpr(scanner parser.Scanner, n, s Expr) Expr {
 	return &OffsetExpr{ExprScanner{scanner}, n, s}
 }
@@ -45,5 +52,5 @@ func (o *OffsetExpr) Eval(local Scope) (_ Value, err error) {
 }
 
 func (o *OffsetExpr) String() string {
-	return fmt.Sprintf("(%s <: %s)", o.offset, o.array)
+	return fmt.Sprintf("(%s \\ %s)", o.offset, o.array)
 }
@@ -0,0 +1,70 @@
+package rel
+
+import (
+	"fmt"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// OrderByNullsExpr is `a orderby key nulls first|last`: like the plain
+// `orderby`, but elements whose key resolves to a missing attr (e.g.
+// `\t t.attr` where a tuple t lacks attr) are treated as having a missing
+// key rather than failing the whole expression, and sort as a block either
+// before or after every element with a present key, per nullsFirst.
+type OrderByNullsExpr struct {
+	ExprScanner
+	a, key     Expr
+	nullsFirst bool
+}
+
+// NewOrderByNullsExpr returns a new OrderByNullsExpr.
+func NewOrderByNullsExpr(scanner parser.Scanner, a, key Expr, nullsFirst bool) Expr {
+	return &OrderByNullsExpr{ExprScanner{scanner}, a, ExprAsFunction(key), nullsFirst}
+}
+
+func (e *OrderByNullsExpr) nullsOrder() string {
+	if e.nullsFirst {
+		return "first"
+	}
+	return "last"
+}
+
+// String returns a string representation of the expression.
+func (e *OrderByNullsExpr) String() string {
+	return fmt.Sprintf("(%s orderby %s nulls %s)", e.a, e.key, e.nullsOrder())
+}
+
+// Eval returns an Array of a's elements, sorted by key, with elements whose
+// key is missing sorted per nullsFirst.
+func (e *OrderByNullsExpr) Eval(local Scope) (Value, error) {
+	aVal, err := e.a.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	x, ok := aVal.(Set)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("'orderby' lhs must be a Set, not %T", aVal), e, local)
+	}
+	keyVal, err := e.key.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	k, ok := keyVal.(Closure)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("'orderby' rhs must be a Fn, not %T", keyVal), e, local)
+	}
+	values, err := OrderByNulls(x,
+		func(value Value) (Value, error) {
+			return SetCall(k, value)
+		},
+		func(a, b Value) bool {
+			return a.Less(b)
+		},
+		e.nullsFirst,
+	)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	return NewArray(values...), nil
+}
@@ -0,0 +1,80 @@
+package rel
+
+import (
+	"fmt"
+
+	"github.com/arr-ai/wbnf/parser"
+)
+
+// OrderByWhereExpr is the fused form of `s orderby key where pred`: it
+// evaluates to the same Array as `(s where pred) orderby key`, but does so
+// in a single pass over s, only sorting the elements pred keeps rather than
+// sorting all of s and then filtering. Unlike naively filtering s before
+// calling orderby, it still checks key-kind consistency (see
+// checkConsistentKinds) across every element of s, not just the survivors,
+// so fusing can't weaken the error orderby would otherwise raise on a
+// mixed-kind key.
+type OrderByWhereExpr struct {
+	ExprScanner
+	a, pred, key Expr
+}
+
+// NewOrderByWhereExpr returns a new OrderByWhereExpr.
+func NewOrderByWhereExpr(scanner parser.Scanner, a, pred, key Expr) Expr {
+	return &OrderByWhereExpr{ExprScanner{scanner}, a, ExprAsFunction(pred), ExprAsFunction(key)}
+}
+
+// String returns a string representation of the expression.
+func (e *OrderByWhereExpr) String() string {
+	return fmt.Sprintf("(%s orderby %s where %s)", e.a, e.key, e.pred)
+}
+
+// Eval evaluates a, pred and key, then returns the elements of a satisfying
+// pred, sorted by key.
+func (e *OrderByWhereExpr) Eval(local Scope) (Value, error) {
+	aVal, err := e.a.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	x, ok := aVal.(Set)
+	if !ok {
+		return nil, WrapContext(fmt.Errorf("orderby lhs must be a Set, not %T", aVal), e, local)
+	}
+
+	predVal, err := e.pred.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	p, ok := predVal.(Closure)
+	if !ok {
+		return nil, WrapContext(fmt.Errorf("where rhs must be a Fn, not %T", predVal), e, local)
+	}
+
+	keyVal, err := e.key.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	k, ok := keyVal.(Closure)
+	if !ok {
+		return nil, WrapContext(fmt.Errorf("orderby rhs must be a Fn, not %T", keyVal), e, local)
+	}
+
+	values, err := OrderByFiltered(x,
+		func(value Value) (bool, error) {
+			r, err := SetCall(p, value)
+			if err != nil {
+				return false, err
+			}
+			return r.IsTrue(), nil
+		},
+		func(value Value) (Value, error) {
+			return SetCall(k, value)
+		},
+		func(a, b Value) bool {
+			return a.Less(b)
+		})
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	return NewArray(values...), nil
+}
@@ -0,0 +1,96 @@
+package rel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// PadExpr is `padleft(s, width, ch)` or `padright(s, width, ch)`: s padded
+// with copies of the single-rune fill character ch until it reaches width
+// runes, on the left or right per left. Strings already at or beyond width
+// are returned unchanged.
+type PadExpr struct {
+	ExprScanner
+	left         bool
+	s, width, ch Expr
+}
+
+// NewPadExpr returns a new PadExpr.
+func NewPadExpr(scanner parser.Scanner, left bool, s, width, ch Expr) Expr {
+	return &PadExpr{ExprScanner{scanner}, left, s, width, ch}
+}
+
+// String returns a string representation of the expression.
+func (e *PadExpr) String() string {
+	name := "padright"
+	if e.left {
+		name = "padleft"
+	}
+	return fmt.Sprintf("%s(%s, %s, %s)", name, e.s, e.width, e.ch)
+}
+
+func (e *PadExpr) name() string {
+	if e.left {
+		return "padleft"
+	}
+	return "padright"
+}
+
+// Eval pads s to width with ch, erroring if ch isn't exactly one rune.
+func (e *PadExpr) Eval(local Scope) (Value, error) {
+	sVal, err := e.s.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	sSet, ok := sVal.(Set)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("%s s must be a String, not %T", e.name(), sVal), e, local)
+	}
+	s, ok := AsString(sSet)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("%s s must be a String, not %T", e.name(), sVal), e, local)
+	}
+
+	widthVal, err := e.width.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	widthNum, ok := widthVal.(Number)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("%s width must be a Number, not %T", e.name(), widthVal), e, local)
+	}
+
+	chVal, err := e.ch.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	chSet, ok := chVal.(Set)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("%s ch must be a String, not %T", e.name(), chVal), e, local)
+	}
+	chStr, ok := AsString(chSet)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("%s ch must be a String, not %T", e.name(), chVal), e, local)
+	}
+	chRunes := []rune(chStr.String())
+	if len(chRunes) != 1 {
+		return nil, WrapContext(
+			errors.Errorf("%s ch must be a single-rune String, not %q", e.name(), chStr.String()), e, local)
+	}
+
+	width := int(widthNum.Float64())
+	str := s.String()
+	if n := width - len([]rune(str)); n > 0 {
+		fill := strings.Repeat(string(chRunes[0]), n)
+		if e.left {
+			str = fill + str
+		} else {
+			str += fill
+		}
+	}
+
+	return NewString([]rune(str)), nil
+}
@@ -0,0 +1,82 @@
+package rel
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// ParseNumError wraps a strconv parse failure from ParseIntExpr so it can be
+// caught with `try parseNum(...) catch parseNum handler`.
+type ParseNumError struct {
+	ctxErr error
+}
+
+func (p ParseNumError) Error() string {
+	return p.ctxErr.Error()
+}
+
+// IsParseNumError reports whether err is a ParseNumError, or a ContextErr
+// directly wrapping one, e.g. as produced by ParseIntExpr failing to parse
+// its input.
+func IsParseNumError(err error) bool {
+	if ctxErr, ok := err.(ContextErr); ok {
+		_, ok := ctxErr.NextErr().(ParseNumError)
+		return ok
+	}
+	_, ok := err.(ParseNumError)
+	return ok
+}
+
+// ParseIntExpr is `parseNum(s, base)`: parses String s as an integer in the
+// given base, 0 meaning auto-detect a "0x"/"0o"/"0b" prefix (defaulting to
+// decimal), per strconv.ParseInt. A malformed s produces a catchable
+// ParseNumError instead of panicking.
+type ParseIntExpr struct {
+	ExprScanner
+	s, base Expr
+}
+
+// NewParseIntExpr returns a new ParseIntExpr.
+func NewParseIntExpr(scanner parser.Scanner, s, base Expr) Expr {
+	return &ParseIntExpr{ExprScanner{scanner}, s, base}
+}
+
+// String returns a string representation of the expression.
+func (e *ParseIntExpr) String() string {
+	return fmt.Sprintf("parseNum(%s, %s)", e.s, e.base)
+}
+
+// Eval parses s in the given base, returning a ParseNumError on failure.
+func (e *ParseIntExpr) Eval(local Scope) (Value, error) {
+	sVal, err := e.s.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	sSet, ok := sVal.(Set)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("parseNum s must be a String, not %T", sVal), e, local)
+	}
+	s, ok := AsString(sSet)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("parseNum s must be a String, not %T", sVal), e, local)
+	}
+
+	baseVal, err := e.base.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	baseNum, ok := baseVal.(Number)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("parseNum base must be a Number, not %T", baseVal), e, local)
+	}
+
+	n, err := strconv.ParseInt(s.String(), int(baseNum.Float64()), 64)
+	if err != nil {
+		return nil, WrapContext(ParseNumError{errors.Errorf("parseNum: %s", err)}, e, local)
+	}
+
+	return NewNumber(float64(n)), nil
+}
@@ -0,0 +1,226 @@
+package rel
+
+import (
+	"fmt"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// PathGetExpr is `getPath(value, path)`: traverses value by path, an Array
+// of String tuple-attribute names and/or Number array indices, returning
+// the value found there. A missing attribute, an out-of-range index, or a
+// segment of the wrong kind for the value being traversed produces a
+// catchable PathError.
+type PathGetExpr struct {
+	ExprScanner
+	value, path Expr
+}
+
+// NewPathGetExpr returns a new PathGetExpr.
+func NewPathGetExpr(scanner parser.Scanner, value, path Expr) Expr {
+	return &PathGetExpr{ExprScanner{scanner}, value, path}
+}
+
+// String returns a string representation of the expression.
+func (e *PathGetExpr) String() string {
+	return fmt.Sprintf("getPath(%s, %s)", e.value, e.path)
+}
+
+// Eval evaluates value and path, then traverses value by path.
+func (e *PathGetExpr) Eval(local Scope) (Value, error) {
+	value, err := e.value.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	segments, err := evalPathSegments(e.path, local, "getPath")
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+
+	result, err := getPath(value, segments, "getPath")
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	return result, nil
+}
+
+// PathSetExpr is `setPath(value, path, v)`: returns a copy of value with the
+// location named by path, an Array of String tuple-attribute names and/or
+// Number array indices, replaced by v. A missing attribute, an out-of-range
+// index, or a segment of the wrong kind for the value being traversed
+// produces a catchable PathError.
+type PathSetExpr struct {
+	ExprScanner
+	value, path, v Expr
+}
+
+// NewPathSetExpr returns a new PathSetExpr.
+func NewPathSetExpr(scanner parser.Scanner, value, path, v Expr) Expr {
+	return &PathSetExpr{ExprScanner{scanner}, value, path, v}
+}
+
+// String returns a string representation of the expression.
+func (e *PathSetExpr) String() string {
+	return fmt.Sprintf("setPath(%s, %s, %s)", e.value, e.path, e.v)
+}
+
+// Eval evaluates value, path and v, then returns a copy of value with the
+// location named by path replaced by v.
+func (e *PathSetExpr) Eval(local Scope) (Value, error) {
+	value, err := e.value.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	segments, err := evalPathSegments(e.path, local, "setPath")
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	v, err := e.v.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+
+	result, err := setPath(value, segments, v, "setPath")
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	return result, nil
+}
+
+// evalPathSegments evaluates expr to an Array and returns its elements.
+func evalPathSegments(expr Expr, local Scope, op string) ([]Value, error) {
+	value, err := expr.Eval(local)
+	if err != nil {
+		return nil, err
+	}
+	arr, ok := value.(Array)
+	if !ok {
+		return nil, errors.Errorf("%s: path must be an Array, not %T", op, value)
+	}
+	return arr.Values(), nil
+}
+
+// getPath traverses value by segments, returning the value found there.
+func getPath(value Value, segments []Value, op string) (Value, error) {
+	cur := value
+	for i, seg := range segments {
+		next, err := stepPath(cur, seg, op, i)
+		if err != nil {
+			return nil, err
+		}
+		cur = next
+	}
+	return cur, nil
+}
+
+// stepPath descends one segment into cur, returning the child found there.
+func stepPath(cur Value, seg Value, op string, i int) (Value, error) {
+	switch c := cur.(type) {
+	case Tuple:
+		name, ok := seg.(String)
+		if !ok {
+			return nil, PathError{errors.Errorf(
+				"%s: path segment %d must be a string to index a tuple, not %T", op, i, seg)}
+		}
+		child, has := c.Get(name.String())
+		if !has {
+			return nil, PathError{errors.Errorf("%s: no such attribute %q at path segment %d", op, name, i)}
+		}
+		return child, nil
+	case Array:
+		num, ok := seg.(Number)
+		if !ok {
+			return nil, PathError{errors.Errorf(
+				"%s: path segment %d must be a number to index an array, not %T", op, i, seg)}
+		}
+		idx, whole := num.Int()
+		values := c.Values()
+		if !whole || idx < 0 || idx >= len(values) {
+			return nil, PathError{errors.Errorf("%s: index %v out of range at path segment %d", op, num, i)}
+		}
+		return values[idx], nil
+	default:
+		return nil, PathError{errors.Errorf("%s: cannot index into %T at path segment %d", op, cur, i)}
+	}
+}
+
+// setPath returns a copy of value with the location named by segments
+// replaced by v.
+func setPath(value Value, segments []Value, v Value, op string) (Value, error) {
+	return setPathAt(value, segments, v, op, 0)
+}
+
+func setPathAt(value Value, segments []Value, v Value, op string, i int) (Value, error) {
+	if len(segments) == 0 {
+		return v, nil
+	}
+
+	seg := segments[0]
+	switch c := value.(type) {
+	case Tuple:
+		name, ok := seg.(String)
+		if !ok {
+			return nil, PathError{errors.Errorf(
+				"%s: path segment %d must be a string to index a tuple, not %T", op, i, seg)}
+		}
+		child, has := c.Get(name.String())
+		if !has {
+			return nil, PathError{errors.Errorf("%s: no such attribute %q at path segment %d", op, name, i)}
+		}
+		if len(segments) == 1 {
+			return c.With(name.String(), v), nil
+		}
+		newChild, err := setPathAt(child, segments[1:], v, op, i+1)
+		if err != nil {
+			return nil, err
+		}
+		return c.With(name.String(), newChild), nil
+	case Array:
+		num, ok := seg.(Number)
+		if !ok {
+			return nil, PathError{errors.Errorf(
+				"%s: path segment %d must be a number to index an array, not %T", op, i, seg)}
+		}
+		idx, whole := num.Int()
+		values := append([]Value{}, c.Values()...)
+		if !whole || idx < 0 || idx >= len(values) {
+			return nil, PathError{errors.Errorf("%s: index %v out of range at path segment %d", op, num, i)}
+		}
+		if len(segments) == 1 {
+			values[idx] = v
+		} else {
+			newChild, err := setPathAt(values[idx], segments[1:], v, op, i+1)
+			if err != nil {
+				return nil, err
+			}
+			values[idx] = newChild
+		}
+		return NewArray(values...), nil
+	default:
+		return nil, PathError{errors.Errorf("%s: cannot index into %T at path segment %d", op, value, i)}
+	}
+}
+
+// PathError wraps a getPath/setPath traversal failure so it can be caught
+// with `try getPath(...) catch getPath handler` or
+// `try setPath(...) catch setPath handler`.
+type PathError struct {
+	ctxErr error
+}
+
+func (p PathError) Error() string {
+	return p.ctxErr.Error()
+}
+
+// IsPathError reports whether err is a PathError, or a ContextErr directly
+// wrapping one, e.g. as produced by getPath/setPath failing to find a path
+// segment.
+func IsPathError(err error) bool {
+	if ctxErr, ok := err.(ContextErr); ok {
+		_, ok := ctxErr.NextErr().(PathError)
+		return ok
+	}
+	_, ok := err.(PathError)
+	return ok
+}
@@ -0,0 +1,78 @@
+package rel
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// ReadFileExpr implements `readFile(path)` (class "readFile", producing a
+// Bytes Set) and `readFileStr(path)` (class "readFileStr", producing a
+// String), reading from fsys. A missing or unreadable file produces a
+// catchable ReadFileError.
+type ReadFileExpr struct {
+	ExprScanner
+	class string
+	path  Expr
+	fsys  fs.FS
+}
+
+// NewReadFileExpr returns a new ReadFileExpr. fsys defaults to the OS
+// filesystem, rooted at "/", when nil.
+func NewReadFileExpr(scanner parser.Scanner, class string, path Expr, fsys fs.FS) Expr {
+	if fsys == nil {
+		fsys = os.DirFS("/")
+	}
+	return &ReadFileExpr{ExprScanner{scanner}, class, path, fsys}
+}
+
+// String returns a string representation of the expression.
+func (e *ReadFileExpr) String() string {
+	return fmt.Sprintf("%s(%s)", e.class, e.path)
+}
+
+// Eval evaluates path, then reads its contents from e.fsys, failing with a
+// catchable ReadFileError if the file cannot be read.
+func (e *ReadFileExpr) Eval(local Scope) (Value, error) {
+	path, err := evalString(e.path, local, e.class)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+
+	data, err := fs.ReadFile(e.fsys, strings.TrimPrefix(path, "/"))
+	if err != nil {
+		return nil, WrapContext(ReadFileError{errors.Errorf("%s: %s", e.class, err)}, e, local)
+	}
+
+	if e.class == "readFileStr" {
+		return NewString([]rune(string(data))), nil
+	}
+	return NewBytes(data), nil
+}
+
+// ReadFileError wraps a file-read failure from ReadFileExpr so it can be
+// caught with `try readFile(...) catch readFile handler` or
+// `try readFileStr(...) catch readFileStr handler`.
+type ReadFileError struct {
+	ctxErr error
+}
+
+func (p ReadFileError) Error() string {
+	return p.ctxErr.Error()
+}
+
+// IsReadFileError reports whether err is a ReadFileError, or a ContextErr
+// directly wrapping one, e.g. as produced by ReadFileExpr failing to read a
+// missing file.
+func IsReadFileError(err error) bool {
+	if ctxErr, ok := err.(ContextErr); ok {
+		_, ok := ctxErr.NextErr().(ReadFileError)
+		return ok
+	}
+	_, ok := err.(ReadFileError)
+	return ok
+}
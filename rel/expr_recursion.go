@@ -15,6 +15,14 @@ type RecursionExpr struct {
 }
 
 func NewRecursionExpr(scanner parser.Scanner, name Expr, fn Expr, fix, fixt Value) Expr {
+	if ident, ok := name.(IdentExpr); ok {
+		if fnLit, ok := fn.(*Function); ok {
+			if branches, controlVarExpr, ok := detectTailRecursion(ident.ident, fnLit); ok {
+				body := NewTailRecursionExpr(scanner, fnLit.arg, controlVarExpr, branches)
+				return NewFunction(scanner, fnLit.arg, body)
+			}
+		}
+	}
 	return RecursionExpr{ExprScanner{scanner}, NewExprPattern(name), fn, fix, fixt}
 }
 
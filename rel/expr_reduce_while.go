@@ -0,0 +1,89 @@
+package rel
+
+import (
+	"fmt"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// ReduceWhileExpr is `reduce init while cond do step` (optionally followed by
+// `cap cap`): init is the starting accumulator; cond and step are
+// single-arg functions taking the accumulator. While cond(acc) is true, acc
+// is replaced by step(acc); the loop ends and acc is returned as soon as
+// cond(acc) is false. If cap is given and the loop runs more than cap
+// iterations, Eval errors instead of looping forever.
+type ReduceWhileExpr struct {
+	ExprScanner
+	init, cond, step, cap Expr
+}
+
+// NewReduceWhileExpr returns a new ReduceWhileExpr. cap may be nil, meaning
+// the loop is uncapped.
+func NewReduceWhileExpr(scanner parser.Scanner, init, cond, step, cap Expr) Expr {
+	return &ReduceWhileExpr{ExprScanner{scanner}, init, cond, step, cap}
+}
+
+// String returns a string representation of the expression.
+func (e *ReduceWhileExpr) String() string {
+	if e.cap != nil {
+		return fmt.Sprintf("reduce %s while %s do %s cap %s", e.init, e.cond, e.step, e.cap)
+	}
+	return fmt.Sprintf("reduce %s while %s do %s", e.init, e.cond, e.step)
+}
+
+// Eval runs the loop iteratively (not recursively, so it can't blow the Go
+// stack regardless of iteration count) and returns the final accumulator.
+func (e *ReduceWhileExpr) Eval(local Scope) (Value, error) {
+	acc, err := e.init.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+
+	condVal, err := e.cond.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	cond, ok := condVal.(Set)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("reduce while: cond must be a function, not %T", condVal), e, local)
+	}
+
+	stepVal, err := e.step.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	step, ok := stepVal.(Set)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("reduce while: step must be a function, not %T", stepVal), e, local)
+	}
+
+	var cap int
+	if e.cap != nil {
+		capVal, err := e.cap.Eval(local)
+		if err != nil {
+			return nil, WrapContext(err, e, local)
+		}
+		n, ok := capVal.(Number)
+		if !ok {
+			return nil, WrapContext(errors.Errorf("reduce while: cap must be a Number, not %T", capVal), e, local)
+		}
+		cap = int(n.Float64())
+	}
+
+	for i := 0; ; i++ {
+		more, err := SetCall(cond, acc)
+		if err != nil {
+			return nil, WrapContext(err, e, local)
+		}
+		if !more.IsTrue() {
+			return acc, nil
+		}
+		if e.cap != nil && i >= cap {
+			return nil, WrapContext(errors.Errorf("reduce while: exceeded cap of %d iterations", cap), e, local)
+		}
+		if acc, err = SetCall(step, acc); err != nil {
+			return nil, WrapContext(err, e, local)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package rel
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// RegexReplaceExpr is `replace(s, pattern, repl)`: every match of pattern in
+// s is replaced by repl, which may reference capture groups as $1, $name,
+// etc, per regexp.Regexp.ReplaceAllString. A pattern with no matches leaves s
+// unchanged. When pattern is a compile-time constant, it's compiled once (by
+// NewRegexReplaceExpr) and reused for every Eval; otherwise it's compiled
+// fresh from pattern's evaluated value on each Eval.
+type RegexReplaceExpr struct {
+	ExprScanner
+	s, pattern, repl Expr
+	compiled         *regexp.Regexp
+}
+
+// NewRegexReplaceExpr returns a new RegexReplaceExpr. If pattern is a
+// compile-time constant string, it's compiled immediately, returning an
+// error if it's malformed; otherwise compilation is deferred to Eval.
+func NewRegexReplaceExpr(scanner parser.Scanner, s, pattern, repl Expr) (Expr, error) {
+	e := &RegexReplaceExpr{ExprScanner{scanner}, s, pattern, repl, nil}
+	if patVal, ok := ExprAsValue(pattern); ok {
+		if patSet, ok := patVal.(Set); ok {
+			if patStr, ok := AsString(patSet); ok {
+				compiled, err := regexp.Compile(patStr.String())
+				if err != nil {
+					return nil, errors.Errorf("replace: bad pattern %q: %s", patStr.String(), err)
+				}
+				e.compiled = compiled
+			}
+		}
+	}
+	return e, nil
+}
+
+// String returns a string representation of the expression.
+func (e *RegexReplaceExpr) String() string {
+	return fmt.Sprintf("replace(%s, %s, %s)", e.s, e.pattern, e.repl)
+}
+
+// Eval replaces every match of pattern in s with repl.
+func (e *RegexReplaceExpr) Eval(local Scope) (Value, error) {
+	sVal, err := e.s.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	sSet, ok := sVal.(Set)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("replace s must be a String, not %T", sVal), e, local)
+	}
+	s, ok := AsString(sSet)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("replace s must be a String, not %T", sVal), e, local)
+	}
+
+	re := e.compiled
+	if re == nil {
+		patVal, err := e.pattern.Eval(local)
+		if err != nil {
+			return nil, WrapContext(err, e, local)
+		}
+		patSet, ok := patVal.(Set)
+		if !ok {
+			return nil, WrapContext(errors.Errorf("replace pattern must be a String, not %T", patVal), e, local)
+		}
+		patStr, ok := AsString(patSet)
+		if !ok {
+			return nil, WrapContext(errors.Errorf("replace pattern must be a String, not %T", patVal), e, local)
+		}
+		re, err = regexp.Compile(patStr.String())
+		if err != nil {
+			return nil, WrapContext(errors.Errorf("replace: bad pattern %q: %s", patStr.String(), err), e, local)
+		}
+	}
+
+	replVal, err := e.repl.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	replSet, ok := replVal.(Set)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("replace repl must be a String, not %T", replVal), e, local)
+	}
+	repl, ok := AsString(replSet)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("replace repl must be a String, not %T", replVal), e, local)
+	}
+
+	return NewString([]rune(re.ReplaceAllString(s.String(), repl.String()))), nil
+}
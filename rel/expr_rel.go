@@ -20,7 +20,7 @@ func NewRelationExpr(scanner parser.Scanner, names []string, tuples ...[]Expr) (
 		}
 		attrs := make([]AttrExpr, len(names))
 		for i, name := range names {
-			attrs[i] = AttrExpr{ExprScanner{scanner}, name, tuple[i]}
+			attrs[i] = AttrExpr{ExprScanner{scanner}, name, nil, tuple[i]}
 		}
 		if len(attrs) == 2 {
 			if attrs[1].name == "@" {
@@ -91,7 +91,74 @@ func newSetBinExprNoError(scanner parser.Scanner, a, b Expr, op string, f func(x
 
 // NewJoinExpr evaluates a <&> b.
 func NewJoinExpr(scanner parser.Scanner, a, b Expr) Expr {
-	return newSetBinExprNoError(scanner, a, b, "<&>", join)
+	return NewJoinExprWithNames(scanner, a, b, nil, nil)
+}
+
+// NewJoinExprWithNames is like NewJoinExpr, but aNames/bNames, when non-nil
+// (i.e. a's/b's attribute names were statically known to the compiler via
+// StaticRelationAttrNames), are attached to the OTHER side's type-mismatch
+// error, so the runtime error names which relation attributes the failing
+// value was being joined against.
+func NewJoinExprWithNames(scanner parser.Scanner, a, b Expr, aNames, bNames []string) Expr {
+	return newBinExpr(scanner, a, b, "<&>", "(%s <&> %s)",
+		func(a, b Value, _ Scope) (Value, error) {
+			x, ok := a.(Set)
+			if !ok {
+				return nil, joinOperandTypeError("lhs", bNames, a)
+			}
+			y, ok := b.(Set)
+			if !ok {
+				return nil, joinOperandTypeError("rhs", aNames, b)
+			}
+			return join(x, y), nil
+		})
+}
+
+// joinOperandTypeError reports that the <&> operand on side (a Set, as
+// required) wasn't one, naming it and, when the OTHER side's relation
+// attribute names are statically known, what attributes it was being
+// joined against.
+func joinOperandTypeError(side string, otherNames []string, v Value) error {
+	if otherNames != nil {
+		return errors.Errorf("<&> %s must be a Set, not %T (joining against attrs %v)", side, v, otherNames)
+	}
+	return errors.Errorf("<&> %s must be a Set, not %T", side, v)
+}
+
+// StaticRelationAttrNames returns e's relation attribute names when they can
+// be determined without evaluation (e.g. a tuple or set-of-tuples literal,
+// even one whose attr values reference variables), and true. Otherwise it
+// returns nil, false. Compilers can use this to attach schema context (e.g.
+// to join error messages) that would otherwise only be available after a
+// successful Eval.
+func StaticRelationAttrNames(e Expr) (Names, bool) {
+	switch e := e.(type) {
+	case *TupleExpr:
+		names := Names{}
+		for _, attr := range e.attrs {
+			if attr.IsComputed() {
+				return Names{}, false
+			}
+			names = names.With(attr.name)
+		}
+		return names, true
+	case *SetExpr:
+		if len(e.elements) == 0 {
+			return Names{}, true
+		}
+		return StaticRelationAttrNames(e.elements[0])
+	case LiteralExpr:
+		if s, ok := e.literal.(Set); ok {
+			return RelationAttrs(s)
+		}
+	}
+	return Names{}, false
+}
+
+// NewCartesianExpr evaluates a cross b: the Cartesian product of relations a
+// and b, erroring if they share an attribute name.
+func NewCartesianExpr(scanner parser.Scanner, a, b Expr) Expr {
+	return newSetBinExpr(scanner, a, b, "cross", Cartesian)
 }
 
 // NewComposeExpr evaluates a <-> b.
@@ -0,0 +1,59 @@
+package rel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// RepeatStringExpr is `repeatStr(s, n)`: s concatenated with itself n times,
+// e.g. n=0 yields "". This is string repetition specifically; repeating the
+// elements of an Array n times is a different, unrelated operation. n must
+// be a non-negative whole number.
+type RepeatStringExpr struct {
+	ExprScanner
+	s, n Expr
+}
+
+// NewRepeatStringExpr returns a new RepeatStringExpr.
+func NewRepeatStringExpr(scanner parser.Scanner, s, n Expr) Expr {
+	return &RepeatStringExpr{ExprScanner{scanner}, s, n}
+}
+
+// String returns a string representation of the expression.
+func (e *RepeatStringExpr) String() string {
+	return fmt.Sprintf("repeatStr(%s, %s)", e.s, e.n)
+}
+
+// Eval repeats s n times, erroring if n is negative or not a whole number.
+func (e *RepeatStringExpr) Eval(local Scope) (Value, error) {
+	sVal, err := e.s.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	sSet, ok := sVal.(Set)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("repeatStr: s must be a String, not %T", sVal), e, local)
+	}
+	s, ok := AsString(sSet)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("repeatStr: s must be a String, not %T", sVal), e, local)
+	}
+
+	nVal, err := e.n.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	n, ok := nVal.(Number)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("repeatStr: n must be a Number, not %T", nVal), e, local)
+	}
+	count, whole := n.Int()
+	if !whole || count < 0 {
+		return nil, WrapContext(errors.Errorf("repeatStr: n must be a non-negative whole number, not %v", n), e, local)
+	}
+
+	return NewString([]rune(strings.Repeat(s.String(), count))), nil
+}
@@ -0,0 +1,71 @@
+package rel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// ReplaceAllLiteralExpr is `replaceLit(s, old, new)` or `replaceLit(s, old,
+// new, count)`: every non-overlapping occurrence of the literal string old
+// in s is replaced by new, up to count times if given, or all of them
+// otherwise. old must not be empty, since there's no sensible meaning for
+// replacing "between every rune".
+type ReplaceAllLiteralExpr struct {
+	ExprScanner
+	s, old, new, count Expr
+}
+
+// NewReplaceAllLiteralExpr returns a new ReplaceAllLiteralExpr. count may
+// be nil, for the replace-all form.
+func NewReplaceAllLiteralExpr(scanner parser.Scanner, s, old, new, count Expr) Expr {
+	return &ReplaceAllLiteralExpr{ExprScanner{scanner}, s, old, new, count}
+}
+
+// String returns a string representation of the expression.
+func (e *ReplaceAllLiteralExpr) String() string {
+	if e.count == nil {
+		return fmt.Sprintf("replaceLit(%s, %s, %s)", e.s, e.old, e.new)
+	}
+	return fmt.Sprintf("replaceLit(%s, %s, %s, %s)", e.s, e.old, e.new, e.count)
+}
+
+// Eval replaces occurrences of old in s with new, up to count times if
+// given.
+func (e *ReplaceAllLiteralExpr) Eval(local Scope) (Value, error) {
+	s, err := evalString(e.s, local, "replaceLit")
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	old, err := evalString(e.old, local, "replaceLit")
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	if old == "" {
+		return nil, WrapContext(errors.Errorf("replaceLit: old must not be empty"), e, local)
+	}
+	new, err := evalString(e.new, local, "replaceLit")
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+
+	n := -1
+	if e.count != nil {
+		countVal, err := e.count.Eval(local)
+		if err != nil {
+			return nil, WrapContext(err, e, local)
+		}
+		countNum, ok := countVal.(Number)
+		if !ok {
+			return nil, WrapContext(errors.Errorf("replaceLit: count must be a number, not %T", countVal), e, local)
+		}
+		n, ok = countNum.Int()
+		if !ok {
+			return nil, WrapContext(errors.Errorf("replaceLit: count must be a whole number"), e, local)
+		}
+	}
+
+	return NewString([]rune(strings.Replace(s, old, new, n))), nil
+}
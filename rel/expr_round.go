@@ -0,0 +1,77 @@
+package rel
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// RoundExpr is `round(x)`, `round(x, places)`, `floor(x)`, `ceil(x)` or
+// `truncate(x)`: x rounded per class. round (with no places, or places 0)
+// rounds half away from zero, per math.Round; round(x, places) rounds to
+// places decimal places using the same rule.
+type RoundExpr struct {
+	ExprScanner
+	class     string
+	x, places Expr
+}
+
+// NewRoundExpr returns a new RoundExpr. class must be one of "round",
+// "floor", "ceil" or "truncate". places may be nil; it is only used by
+// "round".
+func NewRoundExpr(scanner parser.Scanner, class string, x, places Expr) Expr {
+	return &RoundExpr{ExprScanner{scanner}, class, x, places}
+}
+
+// String returns a string representation of the expression.
+func (e *RoundExpr) String() string {
+	if e.places == nil {
+		return fmt.Sprintf("%s(%s)", e.class, e.x)
+	}
+	return fmt.Sprintf("%s(%s, %s)", e.class, e.x, e.places)
+}
+
+// Eval evaluates x (and places, if given), then rounds x per e.class.
+func (e *RoundExpr) Eval(local Scope) (Value, error) {
+	xVal, err := e.x.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	xNum, ok := xVal.(Number)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("%s: x must be a number, not %T", e.class, xVal), e, local)
+	}
+	x := float64(xNum)
+
+	places := 0
+	if e.places != nil {
+		placesVal, err := e.places.Eval(local)
+		if err != nil {
+			return nil, WrapContext(err, e, local)
+		}
+		placesNum, ok := placesVal.(Number)
+		if !ok {
+			return nil, WrapContext(errors.Errorf("%s: places must be a number, not %T", e.class, placesVal), e, local)
+		}
+		places, ok = placesNum.Int()
+		if !ok {
+			return nil, WrapContext(errors.Errorf("%s: places must be a whole number", e.class), e, local)
+		}
+	}
+
+	scale := math.Pow(10, float64(places))
+	switch e.class {
+	case "floor":
+		return NewNumber(math.Floor(x)), nil
+	case "ceil":
+		return NewNumber(math.Ceil(x)), nil
+	case "truncate":
+		return NewNumber(math.Trunc(x)), nil
+	case "round":
+		return NewNumber(math.Round(x*scale) / scale), nil
+	default:
+		return nil, WrapContext(errors.Errorf("%s: unknown rounding class", e.class), e, local)
+	}
+}
@@ -12,13 +12,19 @@ type SafeTailExpr struct {
 	ExprScanner
 	fallbackValue, base Expr
 	tailExprs           []SafeTailCallback
+	noneGuard           bool
 }
 
-func NewSafeTailExpr(scanner parser.Scanner, fallback, base Expr, tailExprs []SafeTailCallback) Expr {
+// NewSafeTailExpr returns a new SafeTailExpr. If noneGuard is true, the
+// chain also falls back to fallback when a tail step's value is None
+// (e.g. an attr that exists but holds {}), not just when a tail step fails
+// outright (e.g. a missing attr); if false, it keeps the original behaviour
+// of only guarding against outright failures.
+func NewSafeTailExpr(scanner parser.Scanner, fallback, base Expr, tailExprs []SafeTailCallback, noneGuard bool) Expr {
 	if len(tailExprs) == 0 {
 		panic("exprs cannot be empty")
 	}
-	return &SafeTailExpr{ExprScanner{scanner}, fallback, base, tailExprs}
+	return &SafeTailExpr{ExprScanner{scanner}, fallback, base, tailExprs, noneGuard}
 }
 
 func (s *SafeTailExpr) Eval(local Scope) (value Value, err error) {
@@ -31,7 +37,7 @@ func (s *SafeTailExpr) Eval(local Scope) (value Value, err error) {
 		if err != nil {
 			return nil, WrapContext(err, s, local)
 		}
-		if value == nil {
+		if value == nil || (s.noneGuard && value.Equal(None)) {
 			return s.fallbackValue.Eval(local)
 		}
 	}
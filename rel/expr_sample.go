@@ -0,0 +1,79 @@
+package rel
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// SampleExpr is `a sample(n, seed)`: deterministically selects n elements
+// from Set a, using seed to make the selection reproducible across runs.
+// Sampling more than a's size returns all of a.
+type SampleExpr struct {
+	ExprScanner
+	a, n, seed Expr
+}
+
+// NewSampleExpr returns a new SampleExpr.
+func NewSampleExpr(scanner parser.Scanner, a, n, seed Expr) Expr {
+	return &SampleExpr{ExprScanner{scanner}, a, n, seed}
+}
+
+// String returns a string representation of the expression.
+func (e *SampleExpr) String() string {
+	return fmt.Sprintf("%s sample(%s, %s)", e.a, e.n, e.seed)
+}
+
+// Eval sorts a's elements into a canonical order (so the sample doesn't
+// depend on the Set's internal layout), then seeds a PRNG with seed to
+// shuffle and take the first n.
+func (e *SampleExpr) Eval(local Scope) (Value, error) {
+	aVal, err := e.a.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	a, ok := aVal.(Set)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("sample lhs must be a Set, not %T", aVal), e, local)
+	}
+
+	nVal, err := e.n.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	nNum, ok := nVal.(Number)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("sample n must be a Number, not %T", nVal), e, local)
+	}
+
+	seedVal, err := e.seed.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	seedNum, ok := seedVal.(Number)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("sample seed must be a Number, not %T", seedVal), e, local)
+	}
+
+	values := make([]Value, 0, a.Count())
+	for en := a.Enumerator(); en.MoveNext(); {
+		values = append(values, en.Current())
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].Less(values[j]) })
+
+	n := int(nNum.Float64())
+	if n >= len(values) {
+		return a, nil
+	}
+	if n < 0 {
+		n = 0
+	}
+
+	rng := rand.New(rand.NewSource(int64(seedNum.Float64()))) //nolint:gosec
+	rng.Shuffle(len(values), func(i, j int) { values[i], values[j] = values[j], values[i] })
+
+	return NewSet(values[:n]...), nil
+}
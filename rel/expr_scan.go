@@ -0,0 +1,78 @@
+package rel
+
+import (
+	"fmt"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// ScanExpr is `a scan(init, step)`: a running fold over Array a, returning
+// an Array of every intermediate accumulator value, starting with init
+// itself (so the result always has len(a)+1 elements; an empty a yields
+// [init]). step is a two-arg curried function (acc, x) -> newAcc. A
+// non-empty Set has no defined element order, so the running fold would be
+// ill-defined and is rejected; an empty Set (there being only one, they're
+// all equally "ordered") is accepted the same as an empty Array.
+type ScanExpr struct {
+	ExprScanner
+	a, init, step Expr
+}
+
+// NewScanExpr returns a new ScanExpr.
+func NewScanExpr(scanner parser.Scanner, a, init, step Expr) Expr {
+	return &ScanExpr{ExprScanner{scanner}, a, init, step}
+}
+
+// String returns a string representation of the expression.
+func (e *ScanExpr) String() string {
+	return fmt.Sprintf("%s scan(%s, %s)", e.a, e.init, e.step)
+}
+
+// Eval runs the fold, accumulating every intermediate state.
+func (e *ScanExpr) Eval(local Scope) (Value, error) {
+	aVal, err := e.a.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	var values []Value
+	if arr, ok := aVal.(Array); ok {
+		values = arr.values
+	} else if s, ok := aVal.(Set); !ok || s.IsTrue() {
+		return nil, WrapContext(errors.Errorf("scan lhs must be an ordered Array, not %T", aVal), e, local)
+	}
+
+	acc, err := e.init.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+
+	stepVal, err := e.step.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	step, ok := stepVal.(Set)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("scan step must be a Fn, not %T", stepVal), e, local)
+	}
+
+	states := make([]Value, 0, len(values)+1)
+	states = append(states, acc)
+	for _, x := range values {
+		partial, err := SetCall(step, acc)
+		if err != nil {
+			return nil, WrapContext(err, e, local)
+		}
+		partialFn, ok := partial.(Set)
+		if !ok {
+			return nil, WrapContext(errors.Errorf("scan step must take two args, not %T", partial), e, local)
+		}
+		acc, err = SetCall(partialFn, x)
+		if err != nil {
+			return nil, WrapContext(err, e, local)
+		}
+		states = append(states, acc)
+	}
+
+	return NewArray(states...), nil
+}
@@ -0,0 +1,150 @@
+package rel
+
+import (
+	"fmt"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// SliceExpr is `a(lo:hi:step)`: a sub-range of the ordered collection a
+// (Array, Bytes or String), analogous to Python's a[lo:hi:step]. lo, hi and
+// step may each be omitted (nil), defaulting respectively to the start, the
+// end, and 1. Negative lo/hi count from the end, as with ArrayUpdateExpr;
+// out-of-range bounds are clamped rather than erroring, as with slicing in
+// other languages. step, if given, must be positive.
+type SliceExpr struct {
+	ExprScanner
+	a, lo, hi, step Expr
+}
+
+// NewSliceExpr returns a new SliceExpr. lo, hi and step may be nil.
+func NewSliceExpr(scanner parser.Scanner, a, lo, hi, step Expr) Expr {
+	return &SliceExpr{ExprScanner{scanner}, a, lo, hi, step}
+}
+
+// String returns a string representation of the expression.
+func (e *SliceExpr) String() string {
+	lo, hi, step := "", "", ""
+	if e.lo != nil {
+		lo = e.lo.String()
+	}
+	if e.hi != nil {
+		hi = e.hi.String()
+	}
+	if e.step != nil {
+		step = fmt.Sprintf(":%s", e.step)
+	}
+	return fmt.Sprintf("%s(%s:%s%s)", e.a, lo, hi, step)
+}
+
+// Eval evaluates a and slices it according to lo, hi and step.
+func (e *SliceExpr) Eval(local Scope) (Value, error) {
+	a, err := e.a.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+
+	step, err := sliceStep(e.step, local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+
+	switch a := a.(type) {
+	case Array:
+		lo, hi, err := sliceBounds(e.lo, e.hi, local, a.offset, len(a.values))
+		if err != nil {
+			return nil, WrapContext(err, e, local)
+		}
+		values := make([]Value, 0, (hi-lo+step-1)/step)
+		for i := lo; i < hi; i += step {
+			values = append(values, a.values[i])
+		}
+		return NewArray(values...), nil
+	case Bytes:
+		bs := a.Bytes()
+		lo, hi, err := sliceBounds(e.lo, e.hi, local, a.offset, len(bs))
+		if err != nil {
+			return nil, WrapContext(err, e, local)
+		}
+		sliced := make([]byte, 0, (hi-lo+step-1)/step)
+		for i := lo; i < hi; i += step {
+			sliced = append(sliced, bs[i])
+		}
+		return NewBytes(sliced), nil
+	case String:
+		lo, hi, err := sliceBounds(e.lo, e.hi, local, a.offset, len(a.s))
+		if err != nil {
+			return nil, WrapContext(err, e, local)
+		}
+		sliced := make([]rune, 0, (hi-lo+step-1)/step)
+		for i := lo; i < hi; i += step {
+			sliced = append(sliced, a.s[i])
+		}
+		return NewString(sliced), nil
+	}
+	return nil, WrapContext(errors.Errorf("slice not applicable to %T", a), e, local)
+}
+
+// sliceBounds evaluates lo and hi (each possibly nil, defaulting to the
+// start and end respectively), resolves negative indices by counting from
+// the end, and clamps the result to [0, length], relative to offset.
+func sliceBounds(loExpr, hiExpr Expr, local Scope, offset, length int) (lo, hi int, err error) {
+	lo, err = sliceIndex(loExpr, local, offset, length, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+	hi, err = sliceIndex(hiExpr, local, offset, length, length)
+	if err != nil {
+		return 0, 0, err
+	}
+	if hi < lo {
+		hi = lo
+	}
+	return lo, hi, nil
+}
+
+func sliceIndex(e Expr, local Scope, offset, length, def int) (int, error) {
+	if e == nil {
+		return def, nil
+	}
+	v, err := e.Eval(local)
+	if err != nil {
+		return 0, err
+	}
+	n, ok := v.(Number)
+	if !ok {
+		return 0, errors.Errorf("slice index must be a Number, not %T", v)
+	}
+	i := int(n.Float64()) - offset
+	if i < 0 {
+		i += length
+	}
+	switch {
+	case i < 0:
+		return 0, nil
+	case i > length:
+		return length, nil
+	default:
+		return i, nil
+	}
+}
+
+func sliceStep(e Expr, local Scope) (int, error) {
+	if e == nil {
+		return 1, nil
+	}
+	v, err := e.Eval(local)
+	if err != nil {
+		return 0, err
+	}
+	n, ok := v.(Number)
+	if !ok {
+		return 0, errors.Errorf("slice step must be a Number, not %T", v)
+	}
+	step := int(n.Float64())
+	if step <= 0 {
+		return 0, errors.Errorf("slice step must be positive, not %d", step)
+	}
+	return step, nil
+}
@@ -0,0 +1,97 @@
+package rel
+
+import (
+	"fmt"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// SortedInsertExpr is `insertSorted(arr, v)` or `insertSorted(arr, v, key)`:
+// inserts v into arr, an already-sorted Array, at the position that keeps
+// it sorted, returning a new Array. Elements compare by key(element) if key
+// is given, else by the elements themselves (Value.Less). If arr isn't
+// actually sorted, the insertion position is unspecified but Eval still
+// returns a value rather than panicking.
+type SortedInsertExpr struct {
+	ExprScanner
+	arr, v, key Expr
+}
+
+// NewSortedInsertExpr returns a new SortedInsertExpr. key may be nil, for
+// the no-key form.
+func NewSortedInsertExpr(scanner parser.Scanner, arr, v, key Expr) Expr {
+	return &SortedInsertExpr{ExprScanner{scanner}, arr, v, key}
+}
+
+// String returns a string representation of the expression.
+func (e *SortedInsertExpr) String() string {
+	if e.key == nil {
+		return fmt.Sprintf("insertSorted(%s, %s)", e.arr, e.v)
+	}
+	return fmt.Sprintf("insertSorted(%s, %s, %s)", e.arr, e.v, e.key)
+}
+
+// Eval inserts v into arr at the position that keeps it sorted by key (or,
+// with no key, by the elements themselves).
+func (e *SortedInsertExpr) Eval(local Scope) (Value, error) {
+	arrVal, err := e.arr.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	arr, ok := arrVal.(Array)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("insertSorted: arr must be an ordered Array, not %T", arrVal), e, local)
+	}
+
+	v, err := e.v.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+
+	var key Closure
+	hasKey := e.key != nil
+	if hasKey {
+		keyVal, err := e.key.Eval(local)
+		if err != nil {
+			return nil, WrapContext(err, e, local)
+		}
+		key, ok = keyVal.(Closure)
+		if !ok {
+			return nil, WrapContext(errors.Errorf("insertSorted: key must be a Fn, not %T", keyVal), e, local)
+		}
+	}
+
+	less := func(a, b Value) (bool, error) {
+		if !hasKey {
+			return a.Less(b), nil
+		}
+		aKey, err := SetCall(key, a)
+		if err != nil {
+			return false, err
+		}
+		bKey, err := SetCall(key, b)
+		if err != nil {
+			return false, err
+		}
+		return aKey.Less(bKey), nil
+	}
+
+	values := arr.Values()
+	i := 0
+	for ; i < len(values); i++ {
+		before, err := less(v, values[i])
+		if err != nil {
+			return nil, WrapContext(err, e, local)
+		}
+		if before {
+			break
+		}
+	}
+
+	result := make([]Value, 0, len(values)+1)
+	result = append(result, values[:i]...)
+	result = append(result, v)
+	result = append(result, values[i:]...)
+	return NewArray(result...), nil
+}
@@ -0,0 +1,68 @@
+package rel
+
+import (
+	"fmt"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// SplitAtExpr is `splitAt(arr, n)`: splits Array arr into a
+// `(prefix: ..., suffix: ...)` pair at index n, equivalent to `(prefix:
+// arr[:n], suffix: arr[n:])`. n is clamped to [0, len(arr)]; a negative n
+// counts from the end of arr.
+type SplitAtExpr struct {
+	ExprScanner
+	arr, n Expr
+}
+
+// NewSplitAtExpr returns a new SplitAtExpr.
+func NewSplitAtExpr(scanner parser.Scanner, arr, n Expr) Expr {
+	return &SplitAtExpr{ExprScanner{scanner}, arr, n}
+}
+
+// String returns a string representation of the expression.
+func (e *SplitAtExpr) String() string {
+	return fmt.Sprintf("splitAt(%s, %s)", e.arr, e.n)
+}
+
+// Eval evaluates arr and n, then splits arr into a prefix/suffix pair at n.
+func (e *SplitAtExpr) Eval(local Scope) (Value, error) {
+	arrVal, err := e.arr.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	arr, ok := arrVal.(Array)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("splitAt: arr must be an Array, not %T", arrVal), e, local)
+	}
+
+	nVal, err := e.n.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	nNum, ok := nVal.(Number)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("splitAt: n must be a number, not %T", nVal), e, local)
+	}
+	n, ok := nNum.Int()
+	if !ok {
+		return nil, WrapContext(errors.Errorf("splitAt: n must be a whole number"), e, local)
+	}
+
+	values := arr.Values()
+	if n < 0 {
+		n += len(values)
+	}
+	if n < 0 {
+		n = 0
+	}
+	if n > len(values) {
+		n = len(values)
+	}
+
+	return NewTuple(
+		NewAttr("prefix", NewArray(values[:n]...)),
+		NewAttr("suffix", NewArray(values[n:]...)),
+	), nil
+}
@@ -0,0 +1,68 @@
+package rel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arr-ai/wbnf/parser"
+)
+
+// StartsWithExpr is `a startsWith b`: whether String a begins with String
+// b. An empty b is always true.
+type StartsWithExpr struct {
+	ExprScanner
+	a, b Expr
+}
+
+// NewStartsWithExpr returns a new StartsWithExpr.
+func NewStartsWithExpr(scanner parser.Scanner, a, b Expr) Expr {
+	return &StartsWithExpr{ExprScanner{scanner}, a, b}
+}
+
+// String returns a string representation of the expression.
+func (e *StartsWithExpr) String() string {
+	return fmt.Sprintf("(%s startsWith %s)", e.a, e.b)
+}
+
+// Eval evaluates a and b, then tests whether a starts with b.
+func (e *StartsWithExpr) Eval(local Scope) (Value, error) {
+	a, err := evalString(e.a, local, "startsWith")
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	b, err := evalString(e.b, local, "startsWith")
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	return NewBool(strings.HasPrefix(a, b)), nil
+}
+
+// EndsWithExpr is `a endsWith b`: whether String a ends with String b. An
+// empty b is always true.
+type EndsWithExpr struct {
+	ExprScanner
+	a, b Expr
+}
+
+// NewEndsWithExpr returns a new EndsWithExpr.
+func NewEndsWithExpr(scanner parser.Scanner, a, b Expr) Expr {
+	return &EndsWithExpr{ExprScanner{scanner}, a, b}
+}
+
+// String returns a string representation of the expression.
+func (e *EndsWithExpr) String() string {
+	return fmt.Sprintf("(%s endsWith %s)", e.a, e.b)
+}
+
+// Eval evaluates a and b, then tests whether a ends with b.
+func (e *EndsWithExpr) Eval(local Scope) (Value, error) {
+	a, err := evalString(e.a, local, "endsWith")
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	b, err := evalString(e.b, local, "endsWith")
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	return NewBool(strings.HasSuffix(a, b)), nil
+}
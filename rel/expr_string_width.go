@@ -0,0 +1,84 @@
+package rel
+
+import (
+	"fmt"
+	"unicode"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// StringWidthExpr is `displayWidth(s)`: the monospace display width of s, as
+// it would occupy columns in a terminal. This differs from len(s) (rune
+// count): combining marks contribute 0 columns, wide characters such as CJK
+// ideographs contribute 2 columns, and all other runes contribute 1 column.
+type StringWidthExpr struct {
+	ExprScanner
+	s Expr
+}
+
+// NewStringWidthExpr returns a new StringWidthExpr.
+func NewStringWidthExpr(scanner parser.Scanner, s Expr) Expr {
+	return &StringWidthExpr{ExprScanner{scanner}, s}
+}
+
+// String returns a string representation of the expression.
+func (e *StringWidthExpr) String() string {
+	return fmt.Sprintf("displayWidth(%s)", e.s)
+}
+
+// Eval evaluates s and returns its monospace display width.
+func (e *StringWidthExpr) Eval(local Scope) (Value, error) {
+	sVal, err := e.s.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	sSet, ok := sVal.(Set)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("displayWidth: s must be a String, not %T", sVal), e, local)
+	}
+	s, ok := AsString(sSet)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("displayWidth: s must be a String, not %T", sVal), e, local)
+	}
+
+	width := 0
+	for _, r := range s.String() {
+		width += runeWidth(r)
+	}
+	return NewNumber(float64(width)), nil
+}
+
+// wideRanges are the Unicode ranges classified as East Asian Wide or
+// Fullwidth, which occupy two columns in a monospace terminal.
+var wideRanges = []struct {
+	lo, hi rune
+}{
+	{0x1100, 0x115F},   // Hangul Jamo
+	{0x2E80, 0x303E},   // CJK Radicals, Kangxi Radicals, CJK Symbols and Punctuation
+	{0x3041, 0x33FF},   // Hiragana .. CJK Compatibility
+	{0x3400, 0x4DBF},   // CJK Unified Ideographs Extension A
+	{0x4E00, 0x9FFF},   // CJK Unified Ideographs
+	{0xA000, 0xA4CF},   // Yi Syllables and Radicals
+	{0xAC00, 0xD7A3},   // Hangul Syllables
+	{0xF900, 0xFAFF},   // CJK Compatibility Ideographs
+	{0xFE30, 0xFE4F},   // CJK Compatibility Forms
+	{0xFF00, 0xFF60},   // Fullwidth Forms
+	{0xFFE0, 0xFFE6},   // Fullwidth Signs
+	{0x20000, 0x2FFFD}, // CJK Unified Ideographs Extension B and beyond
+	{0x30000, 0x3FFFD}, // CJK Unified Ideographs Extension G and beyond
+}
+
+// runeWidth returns the monospace display width of a single rune: 0 for
+// combining marks, 2 for East Asian wide/fullwidth characters, 1 otherwise.
+func runeWidth(r rune) int {
+	if unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r) {
+		return 0
+	}
+	for _, wr := range wideRanges {
+		if r >= wr.lo && r <= wr.hi {
+			return 2
+		}
+	}
+	return 1
+}
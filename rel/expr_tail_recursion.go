@@ -0,0 +1,116 @@
+package rel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arr-ai/wbnf/parser"
+)
+
+// tailRecursionBranch is one arm of a tail-recursive cond: either a base
+// case (baseExpr, evaluated and returned directly) or a tail call back into
+// the recursive function (nextArg, whose evaluated value becomes the next
+// iteration's argument).
+type tailRecursionBranch struct {
+	pattern  Pattern
+	baseExpr Expr
+	nextArg  Expr
+}
+
+// detectTailRecursion reports whether fn is a single-argument, cond-based
+// function whose body only calls itself (identified by name) in tail
+// position. Every branch of the cond must either avoid referencing name
+// entirely (a base case) or consist of exactly a call `name(arg)` (a tail
+// call); anything else (e.g. `n * fact(n - 1)`, a non-tail call) causes
+// detection to fail, so the caller can fall back to the regular
+// stack-growing fixpoint recursion.
+func detectTailRecursion(name string, fn *Function) ([]tailRecursionBranch, Expr, bool) {
+	cond, ok := fn.body.(CondPatternControlVarExpr)
+	if !ok {
+		return nil, nil, false
+	}
+	branches := make([]tailRecursionBranch, 0, len(cond.conditionPairs))
+	for _, pair := range cond.conditionPairs {
+		if arg, ok := asTailCall(name, pair.expr); ok {
+			branches = append(branches, tailRecursionBranch{pattern: pair.pattern, nextArg: arg})
+			continue
+		}
+		if strings.Contains(pair.expr.String(), name) {
+			return nil, nil, false
+		}
+		branches = append(branches, tailRecursionBranch{pattern: pair.pattern, baseExpr: pair.expr})
+	}
+	return branches, cond.controlVarExpr, true
+}
+
+// asTailCall reports whether expr is exactly a single-argument call to the
+// identifier name, e.g. `name(n - 1)`, returning the call's argument expr.
+func asTailCall(name string, expr Expr) (Expr, bool) {
+	call, ok := expr.(*BinExpr)
+	if !ok || call.op != "call" {
+		return nil, false
+	}
+	if ident, ok := call.a.(IdentExpr); ok && ident.ident == name {
+		return call.b, true
+	}
+	return nil, false
+}
+
+// TailRecursionExpr evaluates a self-recursive function whose recursive
+// calls are all in tail position (see detectTailRecursion) using an
+// iterative loop instead of nested Eval calls, so it doesn't grow the Go
+// call stack on deep recursion.
+type TailRecursionExpr struct {
+	ExprScanner
+	arg            Pattern
+	controlVarExpr Expr
+	branches       []tailRecursionBranch
+}
+
+// NewTailRecursionExpr returns a TailRecursionExpr. arg is the recursive
+// function's formal argument pattern; controlVarExpr and branches come from
+// its cond body, as identified by detectTailRecursion.
+func NewTailRecursionExpr(
+	scanner parser.Scanner, arg Pattern, controlVarExpr Expr, branches []tailRecursionBranch,
+) Expr {
+	return TailRecursionExpr{ExprScanner{scanner}, arg, controlVarExpr, branches}
+}
+
+func (t TailRecursionExpr) Eval(local Scope) (Value, error) {
+	scope := local
+	for {
+		ctrlVal, err := t.controlVarExpr.Eval(scope)
+		if err != nil {
+			return nil, WrapContext(err, t, scope)
+		}
+		for _, br := range t.branches {
+			bound, err := br.pattern.Bind(scope, ctrlVal)
+			if err != nil {
+				continue
+			}
+			full, err := scope.MatchedUpdate(bound)
+			if err != nil {
+				return nil, WrapContext(err, t, scope)
+			}
+			if br.nextArg != nil {
+				nextVal, err := br.nextArg.Eval(full)
+				if err != nil {
+					return nil, WrapContext(err, t, full)
+				}
+				argBound, err := t.arg.Bind(scope, nextVal)
+				if err != nil {
+					return nil, WrapContext(err, t, full)
+				}
+				scope = scope.Update(argBound)
+				goto next
+			}
+			return br.baseExpr.Eval(full)
+		}
+		return None, nil
+	next:
+	}
+}
+
+func (t TailRecursionExpr) String() string {
+	return fmt.Sprintf("\\%s cond %s {...}", t.arg, t.controlVarExpr)
+}
@@ -0,0 +1,76 @@
+package rel
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+var templatePlaceholderRE = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// TemplateExpr is `render(template, value)`: every `{attr}` placeholder in
+// String template is replaced by value's attr, stringified per String's
+// natural representation. Unlike xstr interpolation (`$"...${expr}..."`),
+// template is a runtime String, not compile-time syntax, so it can be
+// built, stored, and reused. A placeholder naming an attr value lacks
+// produces a catchable MissingAttrError, rather than panicking.
+type TemplateExpr struct {
+	ExprScanner
+	template, value Expr
+}
+
+// NewTemplateExpr returns a new TemplateExpr.
+func NewTemplateExpr(scanner parser.Scanner, template, value Expr) Expr {
+	return &TemplateExpr{ExprScanner{scanner}, template, value}
+}
+
+// String returns a string representation of the expression.
+func (e *TemplateExpr) String() string {
+	return fmt.Sprintf("render(%s, %s)", e.template, e.value)
+}
+
+// Eval resolves template's placeholders against value's attrs.
+func (e *TemplateExpr) Eval(local Scope) (Value, error) {
+	templateVal, err := e.template.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	templateSet, ok := templateVal.(Set)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("render template must be a String, not %T", templateVal), e, local)
+	}
+	template, ok := AsString(templateSet)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("render template must be a String, not %T", templateVal), e, local)
+	}
+
+	valueVal, err := e.value.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	tuple, ok := valueVal.(Tuple)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("render value must be a tuple, not %T", valueVal), e, local)
+	}
+
+	var missing error
+	result := templatePlaceholderRE.ReplaceAllStringFunc(template.String(), func(placeholder string) string {
+		if missing != nil {
+			return placeholder
+		}
+		name := placeholder[1 : len(placeholder)-1]
+		attr, ok := tuple.Get(name)
+		if !ok {
+			missing = MissingAttrError{errors.Errorf("render: no such attr %q", name)}
+			return placeholder
+		}
+		return fmt.Sprintf("%s", attr)
+	})
+	if missing != nil {
+		return nil, WrapContext(missing, e, local)
+	}
+
+	return NewString([]rune(result)), nil
+}
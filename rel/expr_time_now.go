@@ -0,0 +1,33 @@
+package rel
+
+import (
+	"time"
+
+	"github.com/arr-ai/wbnf/parser"
+)
+
+// TimeNowExpr is `now()`: the current time, as a Number of seconds since the
+// Unix epoch (with sub-second precision), per clock.
+type TimeNowExpr struct {
+	ExprScanner
+	clock func() time.Time
+}
+
+// NewTimeNowExpr returns a new TimeNowExpr reading the time from clock. A nil
+// clock defaults to the real system clock (time.Now).
+func NewTimeNowExpr(scanner parser.Scanner, clock func() time.Time) Expr {
+	if clock == nil {
+		clock = time.Now
+	}
+	return &TimeNowExpr{ExprScanner{scanner}, clock}
+}
+
+// String returns a string representation of the expression.
+func (e *TimeNowExpr) String() string {
+	return "now()"
+}
+
+// Eval returns the current time per e.clock, as seconds since the Unix epoch.
+func (e *TimeNowExpr) Eval(_ Scope) (Value, error) {
+	return NewNumber(float64(e.clock().UnixNano()) / 1e9), nil
+}
@@ -0,0 +1,77 @@
+package rel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// TrimExpr is `trim(s, cutset)`, `trimLeft(s, cutset)`, `trimRight(s,
+// cutset)`, `trimPrefix(s, prefix)` or `trimSuffix(s, suffix)`: s with
+// leading and/or trailing runes in cutset removed, or with a literal
+// prefix/suffix removed if present. An empty cutset or affix is a no-op.
+type TrimExpr struct {
+	ExprScanner
+	class string
+	s, x  Expr
+}
+
+// NewTrimExpr returns a new TrimExpr. class must be one of "trim",
+// "trimLeft", "trimRight", "trimPrefix" or "trimSuffix".
+func NewTrimExpr(scanner parser.Scanner, class string, s, x Expr) Expr {
+	return &TrimExpr{ExprScanner{scanner}, class, s, x}
+}
+
+// String returns a string representation of the expression.
+func (e *TrimExpr) String() string {
+	return fmt.Sprintf("%s(%s, %s)", e.class, e.s, e.x)
+}
+
+// Eval evaluates s and x, then trims s per e.class.
+func (e *TrimExpr) Eval(local Scope) (Value, error) {
+	s, err := evalString(e.s, local, e.class)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	x, err := evalString(e.x, local, e.class)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+
+	var result string
+	switch e.class {
+	case "trim":
+		result = strings.Trim(s, x)
+	case "trimLeft":
+		result = strings.TrimLeft(s, x)
+	case "trimRight":
+		result = strings.TrimRight(s, x)
+	case "trimPrefix":
+		result = strings.TrimPrefix(s, x)
+	case "trimSuffix":
+		result = strings.TrimSuffix(s, x)
+	default:
+		return nil, WrapContext(errors.Errorf("%s: unknown trim class", e.class), e, local)
+	}
+	return NewString([]rune(result)), nil
+}
+
+// evalString evaluates expr and returns it as a Go string, erroring with a
+// message naming op if it isn't a String.
+func evalString(expr Expr, local Scope, op string) (string, error) {
+	v, err := expr.Eval(local)
+	if err != nil {
+		return "", err
+	}
+	set, ok := v.(Set)
+	if !ok {
+		return "", errors.Errorf("%s: argument must be a String, not %T", op, v)
+	}
+	str, ok := AsString(set)
+	if !ok {
+		return "", errors.Errorf("%s: argument must be a String, not %T", op, v)
+	}
+	return str.String(), nil
+}
@@ -0,0 +1,70 @@
+package rel
+
+import (
+	"fmt"
+
+	"github.com/arr-ai/wbnf/parser"
+)
+
+// TryErrorKinds maps the kind names accepted after `catch` to predicates
+// recognizing the corresponding error type, e.g. as produced by ContextErr-
+// wrapped evaluation failures. Extend this map to let `try`/`catch` catch
+// further error kinds.
+var TryErrorKinds = map[string]func(error) bool{
+	"missingAttr":  IsMissingAttrError,
+	"noReturn":     IsNoReturnError,
+	"parseNum":     IsParseNumError,
+	"fromBase":     IsNumberFormatBaseError,
+	"base64Decode": IsBase64DecodeError,
+	"jsonDecode":   IsJSONDecodeError,
+	"csvDecode":    IsCSVDecodeError,
+	"xmlDecode":    IsXMLDecodeError,
+	"parseTime":    IsClockParseError,
+	"env":          IsEnvMissingError,
+	"readFile":     IsReadFileError,
+	"readFileStr":  IsReadFileError,
+	"getPath":      IsPathError,
+	"setPath":      IsPathError,
+}
+
+// TryExpr is `try body catch kind handler`: body is evaluated, and if it
+// fails with an error of the named kind, handler is evaluated instead;
+// any other error propagates unchanged.
+type TryExpr struct {
+	ExprScanner
+	body, handler Expr
+	kind          string
+	matches       func(error) bool
+}
+
+// NewTryExpr returns a new TryExpr. It panics if kind is not a recognized
+// key of TryErrorKinds.
+func NewTryExpr(scanner parser.Scanner, body Expr, kind string, handler Expr) Expr {
+	matches, ok := TryErrorKinds[kind]
+	if !ok {
+		panic(fmt.Errorf("try catch: unknown error kind %q", kind))
+	}
+	return &TryExpr{ExprScanner{scanner}, body, handler, kind, matches}
+}
+
+// String returns a string representation of the expression.
+func (e *TryExpr) String() string {
+	return fmt.Sprintf("try %s catch %s %s", e.body, e.kind, e.handler)
+}
+
+// Eval evaluates body, falling back to handler iff body fails with an error
+// of the caught kind; any other error propagates unchanged.
+func (e *TryExpr) Eval(local Scope) (Value, error) {
+	value, err := e.body.Eval(local)
+	if err == nil {
+		return value, nil
+	}
+	if !e.matches(err) {
+		return nil, err
+	}
+	value, err = e.handler.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	return value, nil
+}
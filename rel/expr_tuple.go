@@ -7,11 +7,15 @@ import (
 	"github.com/go-errors/errors"
 )
 
-// AttrExpr represents a single name:expr in a TupleExpr.
+// AttrExpr represents a single name:expr in a TupleExpr. If nameExpr is set
+// (a computed attr, written `(::expr: value)`; there is no `(|expr|: value)`
+// form), name is evaluated from it at eval time instead of being fixed at
+// compile time.
 type AttrExpr struct {
 	ExprScanner
-	name string
-	expr Expr
+	name     string
+	nameExpr Expr
+	expr     Expr
 }
 
 // NewAttrExpr constructs a new AttrExpr from the given arguments.
@@ -26,7 +30,7 @@ func NewAttrExpr(scanner parser.Scanner, name string, expr Expr) (AttrExpr, erro
 	if isWildcard != (name == "*") {
 		return AttrExpr{}, errors.Errorf("Wildcard attr cannot have a name")
 	}
-	return AttrExpr{ExprScanner{scanner}, name, expr}, nil
+	return AttrExpr{ExprScanner{scanner}, name, nil, expr}, nil
 }
 
 func MustNewAttrExpr(scanner parser.Scanner, name string, expr Expr) AttrExpr {
@@ -37,14 +41,30 @@ func MustNewAttrExpr(scanner parser.Scanner, name string, expr Expr) AttrExpr {
 	return attrExpr
 }
 
+// NewComputedAttrExpr constructs an AttrExpr whose name is computed at eval
+// time by evaluating nameExpr, which must evaluate to a String.
+func NewComputedAttrExpr(scanner parser.Scanner, nameExpr, expr Expr) AttrExpr {
+	return AttrExpr{ExprScanner{scanner}, "", nameExpr, expr}
+}
+
 // NewWildcardExpr constructs a new wildcard AttrExpr.
 func NewWildcardExpr(scanner parser.Scanner, lhs Expr) AttrExpr {
-	return AttrExpr{ExprScanner{scanner}, "*", lhs}
+	return AttrExpr{ExprScanner{scanner}, "*", nil, lhs}
 }
 
 // IsWildcard returns true iff the AttrExpr is a wildcard expression.
 func (e *AttrExpr) IsWildcard() bool {
-	return e.name == "*"
+	return e.nameExpr == nil && e.name == "*"
+}
+
+// IsComputed returns true iff the AttrExpr's name is computed at eval time.
+func (e *AttrExpr) IsComputed() bool {
+	return e.nameExpr != nil
+}
+
+// NameExpr returns the expr that computes a computed AttrExpr's name.
+func (e *AttrExpr) NameExpr() Expr {
+	return e.nameExpr
 }
 
 // Apply applies the AttrExpr to the Tuple.
@@ -65,7 +85,19 @@ func (e *AttrExpr) Apply(
 		return nil, errors.Errorf(
 			"LHS of wildcard must be tuple, not %T", value)
 	}
-	tuple = tuple.With(e.name, value)
+	name := e.name
+	if e.nameExpr != nil {
+		nameVal, err := e.nameExpr.Eval(local)
+		if err != nil {
+			return nil, err
+		}
+		s, ok := nameVal.(String)
+		if !ok {
+			return nil, errors.Errorf("computed attr name must be a string, not %T(%[1]v)", nameVal)
+		}
+		name = s.String()
+	}
+	tuple = tuple.With(name, value)
 	return tuple, nil
 }
 
@@ -80,12 +112,18 @@ type TupleExpr struct {
 func NewTupleExpr(scanner parser.Scanner, attrs ...AttrExpr) Expr {
 	attrValues := make([]Attr, len(attrs))
 	for i, attr := range attrs {
-		if value, is := exprIsValue(attr.expr); is {
+		// A computed attr's name is never known until eval time, so it can
+		// never be folded into a literal tuple at compile time.
+		if value, is := exprIsValue(attr.expr); is && !attr.IsComputed() {
 			attrValues[i] = Attr{attr.name, value}
 		} else {
 			attrMap := make(map[string]Expr, len(attrs))
 			for _, attr := range attrs {
-				attrMap[attr.name] = attr.expr
+				key := attr.name
+				if attr.IsComputed() {
+					key = attr.nameExpr.String()
+				}
+				attrMap[key] = attr.expr
 			}
 			return &TupleExpr{ExprScanner{scanner}, attrs, attrMap}
 		}
@@ -105,7 +143,7 @@ func NewTupleExprFromMap(scanner parser.Scanner, attrMap map[string]Expr) Expr {
 			attrs := make([]AttrExpr, len(attrMap))
 			i := 0
 			for name, expr := range attrMap {
-				attrs[i] = AttrExpr{ExprScanner{scanner}, name, expr}
+				attrs[i] = AttrExpr{ExprScanner{scanner}, name, nil, expr}
 				i++
 			}
 			return &TupleExpr{ExprScanner{scanner}, attrs, attrMap}
@@ -122,12 +160,18 @@ func (e *TupleExpr) String() string { //nolint:dupl
 		if i > 0 {
 			b.WriteString(", ")
 		}
-		if attr.IsWildcard() {
+		switch {
+		case attr.IsWildcard():
 			if ident, is := attr.expr.(IdentExpr); !is || ident.Ident() != "." {
 				b.WriteString(attr.expr.String())
 			}
 			b.WriteString(".*")
-		} else {
+		case attr.IsComputed():
+			b.WriteString("::")
+			b.WriteString(attr.nameExpr.String())
+			b.WriteString(": ")
+			b.WriteString(attr.expr.String())
+		default:
 			b.WriteString(attr.name)
 			b.WriteString(": ")
 			b.WriteString(attr.expr.String())
@@ -0,0 +1,57 @@
+package rel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// TupleProjectRename is a (new name, old name) pair for a
+// TupleProjectRenameExpr projection.
+type TupleProjectRename struct {
+	New, Old string
+}
+
+// TupleProjectRenameExpr projects a tuple down to a set of attrs, renaming
+// each one, e.g. `t.|b::a|` selects `a` and renames it to `b`.
+type TupleProjectRenameExpr struct {
+	ExprScanner
+	base    Expr
+	renames []TupleProjectRename
+}
+
+// NewTupleProjectRenameExpr returns a new TupleProjectRenameExpr.
+func NewTupleProjectRenameExpr(scanner parser.Scanner, base Expr, renames []TupleProjectRename) Expr {
+	return &TupleProjectRenameExpr{ExprScanner{scanner}, base, renames}
+}
+
+func (tp *TupleProjectRenameExpr) Eval(local Scope) (Value, error) {
+	val, err := tp.base.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, tp, local)
+	}
+	tuple, isTuple := val.(Tuple)
+	if !isTuple {
+		return nil, WrapContext(errors.Errorf("lhs does not evaluate to tuple: %s", val), tp, local)
+	}
+
+	attrs := make([]Attr, 0, len(tp.renames))
+	for _, r := range tp.renames {
+		value, found := tuple.Get(r.Old)
+		if !found {
+			return nil, WrapContext(errors.Errorf("no such attr: %s", r.Old), tp, local)
+		}
+		attrs = append(attrs, Attr{Name: r.New, Value: value})
+	}
+	return NewTuple(attrs...), nil
+}
+
+func (tp *TupleProjectRenameExpr) String() string {
+	pairs := make([]string, 0, len(tp.renames))
+	for _, r := range tp.renames {
+		pairs = append(pairs, fmt.Sprintf("%s::%s", r.New, r.Old))
+	}
+	return fmt.Sprintf("(%s).|%s|", tp.base, strings.Join(pairs, ", "))
+}
@@ -54,6 +54,14 @@ func NewNotExpr(scanner parser.Scanner, a Expr) Expr {
 		func(a Value, _ Scope) (Value, error) { return NewBool(!a.IsTrue()), nil })
 }
 
+// NewCoerceBoolExpr evaluates to bool(a): a's canonical truthiness coercion,
+// i.e. the same empty/zero-is-false rule IsTrue applies implicitly in
+// And/Or/cond/if, made explicit as a Bool value.
+func NewCoerceBoolExpr(scanner parser.Scanner, a Expr) Expr {
+	return newUnaryExpr(scanner, a, "bool", "bool(%s)",
+		func(a Value, _ Scope) (Value, error) { return NewBool(a.IsTrue()), nil })
+}
+
 // NewEvalExpr evaluates to *a, given a set lhs.
 func NewEvalExpr(scanner parser.Scanner, a Expr) Expr {
 	return newUnaryExpr(scanner, a, "*", "(*%s)",
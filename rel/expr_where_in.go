@@ -0,0 +1,96 @@
+package rel
+
+import (
+	"fmt"
+)
+
+// WhereInExpr is an optimized form of `s where \t t.attr <: allowed`,
+// recognized at compile time when allowed is a literal Set. It filters a
+// directly against allowed.Has, skipping the general pattern-bind/closure
+// machinery that the unoptimized `where` goes through for every element.
+type WhereInExpr struct {
+	ExprScanner
+	original Expr
+	a        Expr
+	attr     string
+	allowed  Set
+}
+
+// NewWhereInExpr returns a WhereInExpr filtering a to the elements whose
+// attr is a member of allowed. original is kept only for String()/Source(),
+// so a WhereInExpr prints exactly like the `where` expression it replaces.
+func NewWhereInExpr(original, a Expr, attr string, allowed Set) WhereInExpr {
+	return WhereInExpr{ExprScanner{original.Source()}, original, a, attr, allowed}
+}
+
+// String returns a string representation of the expression.
+func (e WhereInExpr) String() string {
+	return e.original.String()
+}
+
+// Eval returns the elements of a whose attr is a member of allowed.
+func (e WhereInExpr) Eval(local Scope) (Value, error) {
+	aVal, err := e.a.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	s, ok := aVal.(Set)
+	if !ok {
+		return nil, WrapContext(fmt.Errorf("'where' lhs must be a Set, not %T", aVal), e, local)
+	}
+	result, err := s.Where(func(v Value) (bool, error) {
+		t, ok := v.(Tuple)
+		if !ok {
+			return false, fmt.Errorf("where .%s <: ...: element is not a tuple: %v", e.attr, v)
+		}
+		attrVal, found := t.Get(e.attr)
+		if !found {
+			return false, fmt.Errorf("where .%s <: ...: tuple has no attr %q: %v", e.attr, e.attr, v)
+		}
+		return e.allowed.Has(attrVal), nil
+	})
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	return result, nil
+}
+
+// detectWhereIn recognizes a `where` predicate of the shape
+// `\t t.attr <: allowed`, where t is the predicate's own argument and
+// allowed is a value already known at compile time, e.g. a literal set.
+// It returns the attr name and allowed set, and true, or false if the
+// predicate doesn't match this shape.
+func detectWhereIn(fn *Function) (string, Set, bool) {
+	argPattern, ok := fn.arg.(ExprPattern)
+	if !ok {
+		return "", nil, false
+	}
+	argIdent, ok := argPattern.Expr.(IdentExpr)
+	if !ok {
+		return "", nil, false
+	}
+
+	cmp, ok := fn.body.(CompareExpr)
+	if !ok || len(cmp.args) != 2 || cmp.ops[0] != "<:" {
+		return "", nil, false
+	}
+
+	dot, ok := cmp.args[0].(*DotExpr)
+	if !ok {
+		return "", nil, false
+	}
+	dotIdent, ok := dot.lhs.(IdentExpr)
+	if !ok || dotIdent.ident != argIdent.ident {
+		return "", nil, false
+	}
+
+	allowedVal, ok := exprIsValue(cmp.args[1])
+	if !ok {
+		return "", nil, false
+	}
+	allowed, ok := allowedVal.(Set)
+	if !ok {
+		return "", nil, false
+	}
+	return dot.attr, allowed, true
+}
@@ -0,0 +1,89 @@
+package rel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// WrapExpr is `wordWrap(s, width)`: s word-wrapped to width columns. Existing
+// newlines in s are preserved as paragraph breaks, and each paragraph is
+// wrapped independently by greedily packing whitespace-separated words onto
+// lines no wider than width. A word longer than width is not broken across
+// lines; it is placed alone on its own (over-long) line instead.
+type WrapExpr struct {
+	ExprScanner
+	s, width Expr
+}
+
+// NewWrapExpr returns a new WrapExpr.
+func NewWrapExpr(scanner parser.Scanner, s, width Expr) Expr {
+	return &WrapExpr{ExprScanner{scanner}, s, width}
+}
+
+// String returns a string representation of the expression.
+func (e *WrapExpr) String() string {
+	return fmt.Sprintf("wordWrap(%s, %s)", e.s, e.width)
+}
+
+// Eval word-wraps s to width columns, erroring if width is not a positive
+// whole number.
+func (e *WrapExpr) Eval(local Scope) (Value, error) {
+	sVal, err := e.s.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	sSet, ok := sVal.(Set)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("wordWrap: s must be a String, not %T", sVal), e, local)
+	}
+	s, ok := AsString(sSet)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("wordWrap: s must be a String, not %T", sVal), e, local)
+	}
+
+	widthVal, err := e.width.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	widthNum, ok := widthVal.(Number)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("wordWrap: width must be a Number, not %T", widthVal), e, local)
+	}
+	width, whole := widthNum.Int()
+	if !whole || width <= 0 {
+		return nil, WrapContext(
+			errors.Errorf("wordWrap: width must be a positive whole number, not %v", widthNum), e, local,
+		)
+	}
+
+	paragraphs := strings.Split(s.String(), "\n")
+	for i, paragraph := range paragraphs {
+		paragraphs[i] = wrapParagraph(paragraph, width)
+	}
+	return NewString([]rune(strings.Join(paragraphs, "\n"))), nil
+}
+
+// wrapParagraph greedily packs the whitespace-separated words of paragraph
+// onto lines no wider than width, without breaking any word.
+func wrapParagraph(paragraph string, width int) string {
+	words := strings.Fields(paragraph)
+	if len(words) == 0 {
+		return paragraph
+	}
+
+	var lines []string
+	line := words[0]
+	for _, word := range words[1:] {
+		if len(line)+1+len(word) <= width {
+			line += " " + word
+		} else {
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	lines = append(lines, line)
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,54 @@
+package rel
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/arr-ai/wbnf/parser"
+)
+
+// WriteExpr is `write(sink, value)`: evaluates value, writes its display
+// representation to w, and returns value unchanged so calls can be chained.
+// sink is retained only for String's sake; the sink-name-to-writer
+// resolution, including rejecting unknown sinks, happens at compile time.
+type WriteExpr struct {
+	ExprScanner
+	sink  string
+	w     io.Writer
+	value Expr
+}
+
+// NewWriteExpr returns a new WriteExpr.
+func NewWriteExpr(scanner parser.Scanner, sink string, w io.Writer, value Expr) Expr {
+	return &WriteExpr{ExprScanner{scanner}, sink, w, value}
+}
+
+// String returns a string representation of the expression.
+func (e *WriteExpr) String() string {
+	return fmt.Sprintf("write(%s, %s)", e.sink, e.value)
+}
+
+// Eval evaluates value, writes its display representation to e.w, and
+// returns value.
+func (e *WriteExpr) Eval(local Scope) (Value, error) {
+	value, err := e.value.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+
+	var s string
+	switch v := value.(type) {
+	case String:
+		s = v.String()
+	case Bytes:
+		s = v.String()
+	default:
+		s = Repr(value)
+	}
+
+	if _, err := io.WriteString(e.w, s); err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+
+	return value, nil
+}
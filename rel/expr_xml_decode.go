@@ -0,0 +1,132 @@
+package rel
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/go-errors/errors"
+)
+
+// XMLDecodeError wraps a malformed-input failure from an XMLDecodeExpr so it
+// can be caught with `try xmlDecode(...) catch xmlDecode handler`.
+type XMLDecodeError struct {
+	ctxErr error
+}
+
+func (x XMLDecodeError) Error() string {
+	return x.ctxErr.Error()
+}
+
+// IsXMLDecodeError reports whether err is an XMLDecodeError, or a ContextErr
+// directly wrapping one, e.g. as produced by an XMLDecodeExpr failing to
+// parse its input.
+func IsXMLDecodeError(err error) bool {
+	if ctxErr, ok := err.(ContextErr); ok {
+		_, ok := ctxErr.NextErr().(XMLDecodeError)
+		return ok
+	}
+	_, ok := err.(XMLDecodeError)
+	return ok
+}
+
+// XMLDecodeExpr is `xmlDecode(s)`: parses String s as XML, mapping the
+// document's root element to a tuple tree `(tag: ..., attrs: (...),
+// children: [...])`, where tag and attrs' names carry "namespace:local" for
+// namespaced names, and children holds nested element tuples interleaved
+// with non-blank text content as Strings. Malformed input produces a
+// catchable XMLDecodeError naming the line, rather than panicking.
+type XMLDecodeExpr struct {
+	ExprScanner
+	s Expr
+}
+
+// NewXMLDecodeExpr returns a new XMLDecodeExpr.
+func NewXMLDecodeExpr(scanner parser.Scanner, s Expr) Expr {
+	return &XMLDecodeExpr{ExprScanner{scanner}, s}
+}
+
+// String returns a string representation of the expression.
+func (e *XMLDecodeExpr) String() string {
+	return fmt.Sprintf("xmlDecode(%s)", e.s)
+}
+
+// Eval parses s as XML, returning an XMLDecodeError on failure.
+func (e *XMLDecodeExpr) Eval(local Scope) (Value, error) {
+	sVal, err := e.s.Eval(local)
+	if err != nil {
+		return nil, WrapContext(err, e, local)
+	}
+	sSet, ok := sVal.(Set)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("xmlDecode s must be a String, not %T", sVal), e, local)
+	}
+	s, ok := AsString(sSet)
+	if !ok {
+		return nil, WrapContext(errors.Errorf("xmlDecode s must be a String, not %T", sVal), e, local)
+	}
+
+	value, err := decodeXML(s.String())
+	if err != nil {
+		return nil, WrapContext(XMLDecodeError{errors.Errorf("xmlDecode: %s", err)}, e, local)
+	}
+	return value, nil
+}
+
+func decodeXML(s string) (Value, error) {
+	dec := xml.NewDecoder(strings.NewReader(s))
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			if err == io.EOF {
+				return nil, errors.Errorf("no root element")
+			}
+			return nil, err
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			return decodeXMLElement(dec, start)
+		}
+	}
+}
+
+func decodeXMLElement(dec *xml.Decoder, start xml.StartElement) (Value, error) {
+	var attrs TupleBuilder
+	for _, attr := range start.Attr {
+		attrs.Put(qualifiedXMLName(attr.Name), NewString([]rune(attr.Value)))
+	}
+
+	var children []Value
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			child, err := decodeXMLElement(dec, t)
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, child)
+		case xml.CharData:
+			if text := strings.TrimSpace(string(t)); text != "" {
+				children = append(children, NewString([]rune(text)))
+			}
+		case xml.EndElement:
+			return NewTuple(
+				NewAttr("tag", NewString([]rune(qualifiedXMLName(start.Name)))),
+				NewAttr("attrs", attrs.Finish()),
+				NewAttr("children", NewArray(children...)),
+			), nil
+		}
+	}
+}
+
+func qualifiedXMLName(name xml.Name) string {
+	if name.Space != "" {
+		return name.Space + ":" + name.Local
+	}
+	return name.Local
+}
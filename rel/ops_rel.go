@@ -160,6 +160,25 @@ var join func(a, b Set) Set = Joiner(func(_ Names, a, b Tuple) Tuple {
 	return Merge(a, b)
 })
 
+// Cartesian computes the Cartesian product of relations a and b: every tuple
+// formed by merging a tuple of a with a tuple of b. Unlike Join, it requires
+// a and b's attribute sets to be disjoint, erroring on a name collision
+// rather than silently matching on the shared attribute.
+func Cartesian(a, b Set) (Set, error) {
+	aNames, ok := RelationAttrs(a)
+	if !ok {
+		return nil, errors.Errorf("Tuple names mismatch in cross lhs")
+	}
+	bNames, ok := RelationAttrs(b)
+	if !ok {
+		return nil, errors.Errorf("Tuple names mismatch in cross rhs")
+	}
+	if common := aNames.Intersect(bNames); common.IsTrue() {
+		return nil, errors.Errorf("cross attrs overlap: %v", common)
+	}
+	return join(a, b), nil
+}
+
 // func Join(a, b Set) Set {
 // 	aNames, ok := RelationAttrs(a)
 // 	if !ok {
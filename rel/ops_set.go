@@ -4,6 +4,7 @@ import (
 	"sort"
 
 	"github.com/arr-ai/frozen"
+	"github.com/go-errors/errors"
 )
 
 // Intersect returns every Value from a that is also in b.
@@ -85,10 +86,108 @@ func OrderBy(s Set, key func(v Value) (Value, error), less func(a, b Value) bool
 			return nil, err
 		}
 	}
+	if err := checkConsistentKinds(o.keys); err != nil {
+		return nil, err
+	}
+	sort.Sort(o)
+	return o.values, nil
+}
+
+// OrderByFiltered is like OrderBy, but only sorts and returns the elements
+// of s for which filter succeeds. Unlike simply calling OrderBy on a
+// pre-filtered set, key-kind consistency (see checkConsistentKinds) is still
+// checked across every element of s, not just the survivors, so filtering
+// out the inconsistent elements can't silently suppress the error orderby
+// would otherwise raise. This lets `s orderby key where pred` compile to
+// filter-then-sort for performance while preserving orderby's full
+// consistent-key-kind guarantee.
+func OrderByFiltered(
+	s Set, filter func(v Value) (bool, error), key func(v Value) (Value, error), less func(a, b Value) bool,
+) ([]Value, error) {
+	n := s.Count()
+	allKeys := make([]Value, 0, n)
+	values := make([]Value, 0, n)
+	keys := make([]Value, 0, n)
+	for e := s.Enumerator(); e.MoveNext(); {
+		v := e.Current()
+		k, err := key(v)
+		if err != nil {
+			return nil, err
+		}
+		allKeys = append(allKeys, k)
+		ok, err := filter(v)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			values = append(values, v)
+			keys = append(keys, k)
+		}
+	}
+	if err := checkConsistentKinds(allKeys); err != nil {
+		return nil, err
+	}
+	o := &orderer{values: values, keys: keys, less: less}
+	sort.Sort(o)
+	return o.values, nil
+}
+
+// OrderByNulls is like OrderBy, but treats a key that fails with a
+// MissingAttrError (see IsMissingAttrError) as a missing key rather than
+// failing outright, sorting such elements as a block either before or after
+// every element with a present key, per nullsFirst. Present keys must still
+// be of a consistent kind among themselves.
+func OrderByNulls(
+	s Set, key func(v Value) (Value, error), less func(a, b Value) bool, nullsFirst bool,
+) ([]Value, error) {
+	n := s.Count()
+	values := make([]Value, 0, n)
+	keys := make([]Value, 0, n)
+	missing := make([]bool, 0, n)
+	presentKeys := make([]Value, 0, n)
+	for e := s.Enumerator(); e.MoveNext(); {
+		v := e.Current()
+		k, err := key(v)
+		if err != nil {
+			if !IsMissingAttrError(err) {
+				return nil, err
+			}
+			values = append(values, v)
+			keys = append(keys, nil)
+			missing = append(missing, true)
+			continue
+		}
+		values = append(values, v)
+		keys = append(keys, k)
+		missing = append(missing, false)
+		presentKeys = append(presentKeys, k)
+	}
+	if err := checkConsistentKinds(presentKeys); err != nil {
+		return nil, err
+	}
+	o := &nullableOrderer{values: values, keys: keys, missing: missing, less: less, nullsFirst: nullsFirst}
 	sort.Sort(o)
 	return o.values, nil
 }
 
+// checkConsistentKinds returns an error if values holds keys of more than one
+// Value kind. Value.Less orders mismatched kinds by Kind() as a tie-breaker,
+// which is rarely what's wanted for orderby/rank keys, so callers that derive
+// keys from a user-supplied function are expected to fail loudly instead.
+func checkConsistentKinds(values []Value) error {
+	if len(values) == 0 {
+		return nil
+	}
+	kind := values[0].Kind()
+	for _, v := range values[1:] {
+		if v.Kind() != kind {
+			return errors.Errorf(
+				"key values must be of a consistent type, got %T and %T", values[0], v)
+		}
+	}
+	return nil
+}
+
 func OrderedValueEnumerator(e ValueEnumerator, less Less) ValueEnumerator {
 	if less == nil {
 		return e
@@ -145,6 +244,41 @@ func (o *orderer) Swap(i, j int) {
 	o.keys[i], o.keys[j] = o.keys[j], o.keys[i]
 }
 
+// nullableOrderer is like orderer, but some keys may be missing, per
+// missing[i]. Missing keys sort as a block before or after every present
+// key, per nullsFirst.
+type nullableOrderer struct {
+	values     []Value
+	keys       []Value
+	missing    []bool
+	less       func(a, b Value) bool
+	nullsFirst bool
+}
+
+// Len is the number of elements in the collection.
+func (o *nullableOrderer) Len() int {
+	return len(o.values)
+}
+
+// Less reports whether the element with
+// index i should sort before the element with index j.
+func (o *nullableOrderer) Less(i, j int) bool {
+	if o.missing[i] || o.missing[j] {
+		if o.missing[i] == o.missing[j] {
+			return false
+		}
+		return o.missing[i] == o.nullsFirst
+	}
+	return o.less(o.keys[i], o.keys[j])
+}
+
+// Swap swaps the elements with indexes i and j.
+func (o *nullableOrderer) Swap(i, j int) {
+	o.values[i], o.values[j] = o.values[j], o.values[i]
+	o.keys[i], o.keys[j] = o.keys[j], o.keys[i]
+	o.missing[i], o.missing[j] = o.missing[j], o.missing[i]
+}
+
 // PowerSet computes the power set of a set.
 func PowerSet(s Set) Set {
 	if gs, ok := s.(GenericSet); ok {
@@ -21,6 +21,13 @@ func Rank(s Set, rankerf func(v Tuple) (Tuple, error)) (Set, error) {
 	ranker := newRanker(entries)
 	for _, attr := range entries[0].ranker.Names().Names() {
 		ranker.attr = attr
+		keys := make([]Value, len(entries))
+		for i, entry := range entries {
+			keys[i] = entry.ranker.MustGet(attr)
+		}
+		if err := checkConsistentKinds(keys); err != nil {
+			return nil, err
+		}
 		sort.Sort(ranker)
 		current := ranker.entries[0].ranker.MustGet(attr)
 		rank := 0
@@ -50,6 +50,11 @@ func NewExprsPattern(exprs ...Expr) ExprsPattern {
 	return ExprsPattern{exprs: exprs}
 }
 
+// Exprs returns the candidate exprs p matches an incoming value against.
+func (p ExprsPattern) Exprs() []Expr {
+	return p.exprs
+}
+
 func (p ExprsPattern) Bind(scope Scope, value Value) (Scope, error) {
 	if len(p.exprs) == 0 {
 		return EmptyScope, errors.Errorf("there is not any rel.Expr in rel.ExprsPattern")
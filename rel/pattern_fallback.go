@@ -34,6 +34,12 @@ func (p FallbackPattern) Bind(local Scope, value Value) (Scope, error) {
 	return p.pattern.Bind(EmptyScope, value)
 }
 
+// Pattern returns the pattern a matched value is bound against, ignoring
+// any fallback.
+func (p FallbackPattern) Pattern() Pattern {
+	return p.pattern
+}
+
 func (p FallbackPattern) String() string {
 	if p.fallback == nil {
 		return p.pattern.String()
@@ -0,0 +1,64 @@
+package rel
+
+import "fmt"
+
+// KindPattern matches a value whose runtime kind is the named kind (one of
+// number, string, bytes, array, tuple, set), then binds the value itself to
+// an inner pattern. It is used to compile `cond`'s `::kind name` type
+// refinement patterns.
+type KindPattern struct {
+	kind    string
+	pattern Pattern
+}
+
+// NewKindPattern returns a KindPattern that matches values of kind, binding
+// matched values with pattern. kind must be one of the names accepted by
+// KindPatternKinds.
+func NewKindPattern(kind string, pattern Pattern) KindPattern {
+	if _, ok := KindPatternKinds[kind]; !ok {
+		panic(fmt.Sprintf("unknown kind %q", kind))
+	}
+	return KindPattern{kind: kind, pattern: pattern}
+}
+
+// KindPatternKinds holds the kind names recognized by KindPattern, so
+// callers (e.g. the compiler) can validate a kind keyword before
+// constructing a pattern out of it.
+var KindPatternKinds = map[string]struct{}{
+	"number": {},
+	"string": {},
+	"bytes":  {},
+	"array":  {},
+	"tuple":  {},
+	"set":    {},
+}
+
+func (p KindPattern) Bind(scope Scope, value Value) (Scope, error) {
+	var matches bool
+	switch value.(type) {
+	case Number:
+		matches = p.kind == "number"
+	case String:
+		matches = p.kind == "string"
+	case Bytes:
+		matches = p.kind == "bytes"
+	case Array:
+		matches = p.kind == "array"
+	case Tuple:
+		matches = p.kind == "tuple"
+	case Set:
+		matches = p.kind == "set"
+	}
+	if !matches {
+		return EmptyScope, fmt.Errorf("value %s is not of kind %s", value, p.kind)
+	}
+	return p.pattern.Bind(scope, value)
+}
+
+func (p KindPattern) String() string {
+	return fmt.Sprintf(":%s %s", p.kind, p.pattern)
+}
+
+func (p KindPattern) Bindings() []string {
+	return p.pattern.Bindings()
+}
@@ -28,6 +28,16 @@ func (a *TuplePatternAttr) IsWildcard() bool {
 	return a.name == "*"
 }
 
+// Name returns the tuple attribute this pattern matches against.
+func (a TuplePatternAttr) Name() string {
+	return a.name
+}
+
+// Pattern returns the (fallback-wrapped) pattern a's value must match.
+func (a TuplePatternAttr) Pattern() FallbackPattern {
+	return a.pattern
+}
+
 type TuplePattern struct {
 	attrs []TuplePatternAttr
 }
@@ -42,6 +52,13 @@ func NewTuplePattern(attrs ...TuplePatternAttr) TuplePattern {
 	return TuplePattern{attrs}
 }
 
+// Attrs returns p's attribute patterns, in their declared order, for
+// callers that need to inspect a tuple pattern's shape, e.g. the
+// compiler's union-exhaustiveness check.
+func (p TuplePattern) Attrs() []TuplePatternAttr {
+	return p.attrs
+}
+
 func (p TuplePattern) Bind(local Scope, value Value) (Scope, error) {
 	tuple, is := value.(Tuple)
 	if !is {
@@ -56,10 +56,20 @@ func exprIsValue(expr Expr) (Value, bool) {
 		return expr, true
 	case LiteralExpr:
 		return expr.literal, true
+	case FoldedExpr:
+		return expr.value, true
 	}
 	return nil, false
 }
 
+// ExprAsValue returns the Value that expr already evaluates to without
+// needing a scope, e.g. a literal or a bare Value, and true. If expr can't
+// be resolved to a Value without evaluation, it returns nil, false. Callers
+// can use this to fold operations over compile-time constants.
+func ExprAsValue(expr Expr) (Value, bool) {
+	return exprIsValue(expr)
+}
+
 // Attr is a name/Value pair used to construct a Tuple.
 type Attr struct {
 	Name  string
@@ -138,6 +148,18 @@ func (n NoReturnError) Error() string {
 	return fmt.Sprintf("Call: no return values for input %v from set %v", n.input, n.s)
 }
 
+// IsNoReturnError reports whether err is a NoReturnError, or a ContextErr
+// directly wrapping one, e.g. as produced by calling a Set with an argument
+// for which it has no return value.
+func IsNoReturnError(err error) bool {
+	if ctxErr, ok := err.(ContextErr); ok {
+		_, ok := ctxErr.NextErr().(NoReturnError)
+		return ok
+	}
+	_, ok := err.(NoReturnError)
+	return ok
+}
+
 func SetCall(s Set, arg Value) (Value, error) {
 	result, err := s.CallAll(arg)
 	if err != nil {
@@ -13,6 +13,7 @@ type Function struct {
 	ExprScanner
 	arg  Pattern
 	body Expr
+	id   string
 }
 
 // NewFunction returns a new function.
@@ -20,6 +21,20 @@ func NewFunction(scanner parser.Scanner, arg Pattern, body Expr) Expr {
 	return &Function{ExprScanner: ExprScanner{Src: scanner}, arg: arg, body: body}
 }
 
+// NewFunctionWithID returns a new function carrying id, a caller-supplied
+// identity (e.g. a content hash of its source), retrievable via ID(). Hosts
+// that memoize or cache against compiled functions can key on it instead of
+// the Function's own address.
+func NewFunctionWithID(scanner parser.Scanner, arg Pattern, body Expr, id string) Expr {
+	return &Function{ExprScanner: ExprScanner{Src: scanner}, arg: arg, body: body, id: id}
+}
+
+// ID returns the function's identity, or "" if it wasn't given one via
+// NewFunctionWithID.
+func (f *Function) ID() string {
+	return f.id
+}
+
 // ExprAsFunction returns a function for an expr. If the expr is already a
 // function, returns expr. Otherwise, returns expr wrapper in a function with
 // arg '.'.
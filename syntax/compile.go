@@ -2,10 +2,13 @@ package syntax
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"path"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 	"sync"
@@ -74,10 +77,10 @@ func MustCompile(filePath, source string) rel.Expr {
 func (pc ParseContext) CompileExpr(b ast.Branch) rel.Expr {
 	// Note: please make sure if it is necessary to add new syntax name before `expr`.
 	name, c := which(b,
-		"amp", "arrow", "let", "unop", "binop", "compare", "rbinop", "if", "get",
-		"tail_op", "postfix", "touch", "get", "rel", "set", "dict", "array", "bytes",
+		"amp", "arrow", "let", "letm", "uniondef", "importAs", "unop", "withop", "binop", "compare", "matches",
+		"rbinop", "if", "get", "tail_op", "postfix", "touch", "convert", "get", "rel", "set", "dict", "array", "bytes",
 		"embed", "op", "fn", "pkg", "tuple", "xstr", "IDENT", "STR", "NUM", "CHAR",
-		"cond", exprTag,
+		"cond", "iflet", "reduceWhile", "try", "debug", "hash", "bool", "charClass", "pad", "replace", "replaceLit", "trim", "parseNum", "now", "genid", "rand", "shuffle", "numtheory", "roundFn", "numHelper", "formatTime", "parseTime", "duration", "addDuration", "timeDiff", "env", "glob", "readFile", "write", "diff", "mergeDeep", "getPath", "setPath", "indexOf", "repeatStr", "caseConv", "displayWidth", "wordWrap", "dedent", "indent", "toBase", "fromBase", "bitCount", "checksum", "hmacSha256", "b64", "jsonDecode", "jsonEncode", "csvDecode", "xmlDecode", "render", "record", "approxEqual", "insertSorted", "memo", "interleave", "splitAt", exprTag,
 	)
 	if c == nil {
 		panic(fmt.Errorf("misshapen node AST: %v", b))
@@ -87,19 +90,141 @@ func (pc ParseContext) CompileExpr(b ast.Branch) rel.Expr {
 		return pc.compileArrow(b, name, c)
 	case "let":
 		return pc.compileLet(c)
+	case "letm":
+		return pc.compileLetMulti(c)
+	case "uniondef":
+		return pc.compileUnionDef(c)
+	case "importAs":
+		return pc.compileImportAs(c)
 	case "unop":
 		return pc.compileUnop(b, c)
+	case "withop":
+		return pc.compileWithOp(b, c)
 	case "binop":
 		return pc.compileBinop(b, c)
 	case "compare":
 		return pc.compileCompare(b, c)
+	case "matches":
+		return pc.compileMatches(b)
 	case "rbinop":
 		return pc.compileRbinop(b, c)
 	case "if":
 		return pc.compileIf(b, c)
 	case "cond":
 		return pc.compileCond(c)
-	case "postfix", "touch":
+	case "iflet":
+		return pc.compileIfLet(c)
+	case "reduceWhile":
+		return pc.compileReduceWhile(c)
+	case "try":
+		return pc.compileTry(c)
+	case "debug":
+		return pc.compileDebug(c)
+	case "hash":
+		return pc.compileHash(c)
+	case "bool":
+		return pc.compileCoerceBool(c)
+	case "charClass":
+		return pc.compileCharClass(c)
+	case "pad":
+		return pc.compilePad(c)
+	case "replace":
+		return pc.compileRegexReplace(c)
+	case "replaceLit":
+		return pc.compileReplaceLit(c)
+	case "trim":
+		return pc.compileTrim(c)
+	case "parseNum":
+		return pc.compileParseNum(c)
+	case "now":
+		return pc.compileTimeNow(c)
+	case "genid":
+		return pc.compileGenID(c)
+	case "rand":
+		return pc.compileRand(c)
+	case "shuffle":
+		return pc.compileShuffle(c)
+	case "numtheory":
+		return pc.compileNumTheory(c)
+	case "roundFn":
+		return pc.compileRound(c)
+	case "numHelper":
+		return pc.compileNumHelper(c)
+	case "formatTime":
+		return pc.compileFormatTime(c)
+	case "parseTime":
+		return pc.compileParseTime(c)
+	case "duration":
+		return pc.compileDuration(c)
+	case "addDuration":
+		return pc.compileAddDuration(c)
+	case "timeDiff":
+		return pc.compileTimeDiff(c)
+	case "env":
+		return pc.compileEnv(c)
+	case "glob":
+		return pc.compileGlob(c)
+	case "readFile":
+		return pc.compileReadFile(c)
+	case "write":
+		return pc.compileWrite(c)
+	case "diff":
+		return pc.compileDiff(c)
+	case "mergeDeep":
+		return pc.compileMergeDeep(c)
+	case "getPath":
+		return pc.compileGetPath(c)
+	case "setPath":
+		return pc.compileSetPath(c)
+	case "indexOf":
+		return pc.compileIndexOf(c)
+	case "repeatStr":
+		return pc.compileRepeatStr(c)
+	case "caseConv":
+		return pc.compileCaseConv(c)
+	case "displayWidth":
+		return pc.compileStringWidth(c)
+	case "wordWrap":
+		return pc.compileWrap(c)
+	case "dedent":
+		return pc.compileDedent(c)
+	case "indent":
+		return pc.compileIndent(c)
+	case "toBase":
+		return pc.compileNumberFormatBase(c)
+	case "fromBase":
+		return pc.compileNumberParseBase(c)
+	case "bitCount":
+		return pc.compileBitCount(c)
+	case "checksum":
+		return pc.compileChecksum(c)
+	case "hmacSha256":
+		return pc.compileHmac(c)
+	case "b64":
+		return pc.compileBase64(c)
+	case "jsonDecode":
+		return pc.compileJSONDecode(c)
+	case "jsonEncode":
+		return pc.compileJSONEncode(c)
+	case "csvDecode":
+		return pc.compileCSVDecode(c)
+	case "xmlDecode":
+		return pc.compileXMLDecode(c)
+	case "render":
+		return pc.compileTemplate(c)
+	case "record":
+		return pc.compileRecord(c)
+	case "approxEqual":
+		return pc.compileApproxEqual(c)
+	case "insertSorted":
+		return pc.compileInsertSorted(c)
+	case "memo":
+		return pc.compileMemo(c)
+	case "interleave":
+		return pc.compileInterleave(c)
+	case "splitAt":
+		return pc.compileSplitAt(c)
+	case "postfix", "touch", "convert":
 		return pc.compilePostfixAndTouch(b, c)
 	case "get", "tail_op":
 		return pc.compileCallGet(b)
@@ -143,6 +268,14 @@ func (pc ParseContext) compilePattern(b ast.Branch) rel.Pattern {
 	if ptn := b.One("pattern"); ptn != nil {
 		return pc.compilePattern(ptn.(ast.Branch))
 	}
+	if kind := b.One("kind"); kind != nil {
+		target := kind.(ast.Branch).One("target").One("IDENT").One("").(ast.Leaf).Scanner().String()
+		if _, ok := rel.KindPatternKinds[target]; !ok {
+			panic(fmt.Errorf("unknown kind pattern: %q", target))
+		}
+		inner := pc.compilePattern(b.One("inner").(ast.Branch))
+		return rel.NewKindPattern(target, inner)
+	}
 	if arr := b.One("array"); arr != nil {
 		return pc.compileArrayPattern(arr.(ast.Branch))
 	}
@@ -237,14 +370,16 @@ func (pc ParseContext) compileTuplePattern(b ast.Branch) rel.Pattern {
 					k = v.String()
 				}
 
-				tail := pair.One("tail")
+				// tail ("?" after an explicit name) carries no semantics of its
+				// own; a value's fallback is independent of it and, in the
+				// punned form (":x"), there is no name token for tail to
+				// attach to at all, so fall alone decides whether a fallback
+				// applies.
 				fall := pair.One("v").One("fall")
 				if fall == nil {
 					attrs = append(attrs, rel.NewTuplePatternAttr(k, rel.NewFallbackPattern(v, nil)))
-				} else if tail != nil && fall != nil {
-					attrs = append(attrs, rel.NewTuplePatternAttr(k, rel.NewFallbackPattern(v, pc.CompileExpr(fall.(ast.Branch)))))
 				} else {
-					panic("fallback item does not match")
+					attrs = append(attrs, rel.NewTuplePatternAttr(k, rel.NewFallbackPattern(v, pc.CompileExpr(fall.(ast.Branch)))))
 				}
 			}
 		}
@@ -293,19 +428,67 @@ func (pc ParseContext) compileSetPattern(b ast.Branch) rel.Pattern {
 func (pc ParseContext) compileArrow(b ast.Branch, name string, c ast.Children) rel.Expr {
 	expr := pc.CompileExpr(b[exprTag].(ast.One).Node.(ast.Branch))
 	source := c.Scanner()
+	// fusibleOrderBy, while non-nil, remembers the operands of an `orderby`
+	// arrow-link just compiled, so that an immediately following `where`
+	// link can fuse into filter-then-sort instead of sort-then-filter. Any
+	// other arrow-link clears it, since the fusion only applies when the
+	// two are textually adjacent.
+	var fusibleOrderBy *orderByFusionState
 	if arrows, has := b["arrow"]; has {
 		for _, arrow := range arrows.(ast.Many) {
 			branch := arrow.(ast.Branch)
-			part, d := which(branch, "nest", "unnest", "ARROW", "binding", "FILTER")
+			part, d := which(branch, "nest", "unnest", "sample", "scan", "find", "ARROW", "binding", "FILTER")
 			switch part {
 			case "nest":
 				expr = parseNest(expr, branch["nest"].(ast.One).Node.(ast.Branch))
+				fusibleOrderBy = nil
 			case "unnest":
 				panic("unfinished")
+			case "sample":
+				sampleBranch := branch["sample"].(ast.One).Node.(ast.Branch)
+				n := pc.CompileExpr(sampleBranch.One("n").(ast.Branch))
+				seed := pc.CompileExpr(sampleBranch.One("seed").(ast.Branch))
+				expr = rel.NewSampleExpr(source, expr, n, seed)
+				fusibleOrderBy = nil
+			case "scan":
+				scanBranch := branch["scan"].(ast.One).Node.(ast.Branch)
+				init := pc.CompileExpr(scanBranch.One("init").(ast.Branch))
+				step := pc.CompileExpr(scanBranch.One("step").(ast.Branch))
+				expr = rel.NewScanExpr(source, expr, init, step)
+				fusibleOrderBy = nil
+			case "find":
+				findBranch := branch["find"].(ast.One).Node.(ast.Branch)
+				pred := pc.CompileExpr(findBranch.One("pred").(ast.Branch))
+				var fallback rel.Expr
+				if f := findBranch.One("fb"); f != nil {
+					fallback = pc.CompileExpr(f.(ast.Branch))
+				}
+				expr = rel.NewFindExpr(source, expr, pred, fallback)
+				fusibleOrderBy = nil
 			case "ARROW":
 				op := d.(ast.One).Node.One("").(ast.Leaf).Scanner()
-				f := binops[op.String()]
-				expr = f(b.Scanner(), expr, pc.CompileExpr(arrow.(ast.Branch)[exprTag].(ast.One).Node.(ast.Branch)))
+				rhsNode := arrow.(ast.Branch)[exprTag].(ast.One).Node.(ast.Branch)
+				rhs := pc.CompileExpr(rhsNode)
+				if nulls := branch.One("nulls"); nulls != nil {
+					if op.String() != "orderby" {
+						panic(fmt.Errorf("nulls first/last is only valid with orderby, not %q", op.String()))
+					}
+					nullsFirst := nulls.(ast.Branch).One("order").One("").Scanner().String() == "first"
+					expr = rel.NewOrderByNullsExpr(b.Scanner(), expr, rhs, nullsFirst)
+					fusibleOrderBy = nil
+				} else if op.String() == "where" && fusibleOrderBy != nil && !predicateReferencesPosition(rhsNode) {
+					expr = rel.NewOrderByWhereExpr(b.Scanner(), fusibleOrderBy.lhs, rhs, fusibleOrderBy.key)
+					fusibleOrderBy = nil
+				} else {
+					lhs := expr
+					f := binops[op.String()]
+					expr = f(b.Scanner(), expr, rhs)
+					if op.String() == "orderby" {
+						fusibleOrderBy = &orderByFusionState{lhs: lhs, key: rhs}
+					} else {
+						fusibleOrderBy = nil
+					}
+				}
 			case "binding":
 				rhs := pc.CompileExpr(arrow.(ast.Branch)[exprTag].(ast.One).Node.(ast.Branch))
 				if pattern := arrow.One("pattern"); pattern != nil {
@@ -313,10 +496,12 @@ func (pc ParseContext) compileArrow(b ast.Branch, name string, c ast.Children) r
 					rhs = rel.NewFunction(source, p, rhs)
 				}
 				expr = binops["->"](source, expr, rhs)
+				fusibleOrderBy = nil
 			case "FILTER":
 				pred := pc.CompileExpr(arrow.(ast.Branch))
 				lhs := rel.NewWhereExpr(source, expr, pred)
 				expr = rel.NewDArrowExpr(source, lhs, pred)
+				fusibleOrderBy = nil
 			}
 		}
 	}
@@ -328,196 +513,1571 @@ func (pc ParseContext) compileArrow(b ast.Branch, name string, c ast.Children) r
 	return expr
 }
 
-// let PATTERN                     = EXPR1;      EXPR2
-// let c.(ast.One).Node.One("...") = expr(lhs);  rhs
-// EXPR1 -> \PATTERN EXPR2
-func (pc ParseContext) compileLet(c ast.Children) rel.Expr {
-	exprs := c.(ast.One).Node.Many(exprTag)
-	expr := pc.CompileExpr(exprs[0].(ast.Branch))
-	rhs := pc.CompileExpr(exprs[1].(ast.Branch))
-	source := c.Scanner()
+// orderByFusionState remembers an `orderby`'s pre-sort lhs and sort key so
+// compileArrow can fuse an immediately following `where` into filter-then-
+// sort, which is usually cheaper than the sort-then-filter the source wrote.
+type orderByFusionState struct {
+	lhs, key rel.Expr
+}
+
+// positionAttrs are the attrs NewArray's ArrayItemTuple elements expose that
+// only make sense on an already-sorted array: "@", an element's position,
+// and rel.ArrayItemAttr ("@item"), its wrapped value. A `where` predicate
+// referencing either is operating on the orderby's output shape, not the
+// original elements, so compileArrow's orderby/where fusion must leave it
+// sort-then-filter rather than reorder it ahead of the sort.
+var positionAttrs = map[string]bool{"@": true, rel.ArrayItemAttr: true}
+
+// predicateReferencesPosition reports whether node's subtree contains a
+// reference to a positionAttrs name, via a `.@`/`.@item` projection or the
+// bare `@`/`@item` identifier.
+func predicateReferencesPosition(node ast.Node) bool {
+	b, ok := node.(ast.Branch)
+	if !ok {
+		return false
+	}
+	if leaf, ok := b.One("").(ast.Leaf); ok && positionAttrs[leaf.Scanner().String()] {
+		return true
+	}
+	for _, children := range b {
+		for _, child := range childNodes(children) {
+			if predicateReferencesPosition(child) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// childNodes returns c's child ast.Nodes, whether stored as ast.One or
+// ast.Many.
+func childNodes(c ast.Children) []ast.Node {
+	switch c := c.(type) {
+	case ast.One:
+		return []ast.Node{c.Node}
+	case ast.Many:
+		return c
+	}
+	return nil
+}
+
+// let PATTERN                     = EXPR1;      EXPR2
+// let c.(ast.One).Node.One("...") = expr(lhs);  rhs
+// EXPR1 -> \PATTERN EXPR2
+func (pc ParseContext) compileLet(c ast.Children) rel.Expr {
+	node := c.(ast.One).Node.(ast.Branch)
+	if pc.StrictRebinding {
+		pc.checkSameScopeRebinding("let", node)
+	} else {
+		pc.checkImportAliasCollisionAhead("let", node)
+	}
+	exprs := c.(ast.One).Node.Many(exprTag)
+	expr := pc.CompileExpr(exprs[0].(ast.Branch))
+	rhs := pc.CompileExpr(exprs[1].(ast.Branch))
+	source := c.Scanner()
+
+	p := pc.compilePattern(c.(ast.One).Node.(ast.Branch))
+	rhs = rel.NewFunction(source, p, rhs)
+
+	if c.(ast.One).Node.One("rec") != nil {
+		fix, fixt := FixFuncs()
+		name := p.(rel.ExprPattern).Expr
+		expr = rel.NewRecursionExpr(c.Scanner(), name, expr, fix, fixt)
+	}
+
+	return binops["->"](source, expr, rhs)
+}
+
+// letBinding is one `pattern = value` pair of a multi-binding let.
+type letBinding struct {
+	pattern rel.Pattern
+	value   rel.Expr
+}
+
+// identBoundaryChars are the characters IDENT cannot be made of, used to
+// find whole-identifier occurrences of a name in a snippet of source.
+const identBoundaryChars = `[^0-9$@A-Za-z_]`
+
+// referencesName reports whether source contains name as a standalone
+// identifier. It's a conservative syntactic check over raw source text,
+// not a real free-variable analysis, so it can't tell a genuine reference
+// apart from a shadowing inner binding of the same name; that's fine here
+// since it only needs to catch (and is only used to catch) the forward
+// references compileLetMulti rejects.
+func referencesName(source, name string) bool {
+	re := regexp.MustCompile(identBoundaryChars + regexp.QuoteMeta(name) + identBoundaryChars)
+	return re.MatchString(" " + source + " ")
+}
+
+// compileLetMulti compiles `let p1 = v1, p2 = v2, ...; body`, desugaring to
+// nested single bindings in declaration order:
+//
+//	v1 -> \p1 (v2 -> \p2 (... body))
+//
+// so each value and the body can reference every earlier binding, but (since
+// these bindings are independent, not `rec`) not a later one; a value that
+// references a name bound later in the same let is a compile error.
+func (pc ParseContext) compileLetMulti(c ast.Children) rel.Expr {
+	node := c.(ast.One).Node.(ast.Branch)
+	if pc.StrictRebinding {
+		pc.checkSameScopeRebinding("letm", node)
+	} else {
+		pc.checkImportAliasCollisionAhead("letm", node)
+	}
+	source := c.Scanner()
+
+	bindingNodes := node.Many("bindings")
+	bindings := make([]letBinding, len(bindingNodes))
+	for i, b := range bindingNodes {
+		branch := b.(ast.Branch)
+		bindings[i] = letBinding{
+			pattern: pc.compilePattern(branch),
+			value:   pc.CompileExpr(branch.One(exprTag).(ast.Branch)),
+		}
+	}
+
+	for i, b := range bindings {
+		valueSource := b.value.Source().String()
+		for _, later := range bindings[i+1:] {
+			for _, name := range later.pattern.Bindings() {
+				if referencesName(valueSource, name) {
+					panic(fmt.Errorf(
+						"let: binding %d's value references %q, which is bound later in the same let",
+						i+1, name))
+				}
+			}
+		}
+	}
+
+	expr := pc.CompileExpr(node.One(exprTag).(ast.Branch))
+	for i := len(bindings) - 1; i >= 0; i-- {
+		expr = binops["->"](source, bindings[i].value, rel.NewFunction(source, bindings[i].pattern, expr))
+	}
+	return expr
+}
+
+// unionTagAttr is the attr every constructor a uniondef introduces sets to
+// its own name, so cond can switch on it to tell two variants apart.
+const unionTagAttr = "tag"
+
+// compileUnionDef compiles `|Union| Ctor1(p1, ...) | Ctor2(p1, ...); body`
+// into a let-style chain binding each CtorN to a curried function of its
+// params, returning a tuple tagged with its own name:
+//
+//	CtorN -> \p1 \p2 ... (tag: "CtorN", p1: p1, p2: p2, ...)
+//
+// so cond pattern-matching a result of one of these constructors can switch
+// on its tag attr and destructure its params by name, the same way it would
+// any other tuple. The union's own name exists only to document the group
+// of constructors it introduces; it isn't bound into scope.
+func (pc ParseContext) compileUnionDef(c ast.Children) rel.Expr {
+	node := c.(ast.One).Node.(ast.Branch)
+	pc.checkImportAliasCollisionAhead("uniondef", node)
+	source := c.Scanner()
+
+	ctors := node.Many("ctor")
+	names := make([]string, len(ctors))
+	values := make([]rel.Expr, len(ctors))
+	for i, ctor := range ctors {
+		branch := ctor.(ast.Branch)
+		name := branch.One("ctorName").One("IDENT").One("").(ast.Leaf).Scanner().String()
+		names[i] = name
+
+		var params []string
+		for _, p := range branch.Many("params") {
+			params = append(params, p.(ast.Branch).One("IDENT").One("").(ast.Leaf).Scanner().String())
+		}
+		attrs := make([]rel.AttrExpr, 0, len(params)+1)
+		tagAttr, err := rel.NewAttrExpr(source, unionTagAttr, rel.NewLiteralExpr(source, rel.NewString([]rune(name))))
+		if err != nil {
+			panic(err)
+		}
+		attrs = append(attrs, tagAttr)
+		for _, param := range params {
+			attr, err := rel.NewAttrExpr(source, param, rel.NewIdentExpr(source, param))
+			if err != nil {
+				panic(err)
+			}
+			attrs = append(attrs, attr)
+		}
+
+		value := rel.NewTupleExpr(source, attrs...)
+		for j := len(params) - 1; j >= 0; j-- {
+			value = rel.NewFunction(source, rel.NewExprPattern(rel.NewIdentExpr(source, params[j])), value)
+		}
+		values[i] = value
+	}
+
+	bodyCtx := pc
+	bodyCtx.activeUnionVariants = names
+	expr := bodyCtx.CompileExpr(node.One(exprTag).(ast.Branch))
+	for i := len(ctors) - 1; i >= 0; i-- {
+		expr = binops["->"](source, values[i], rel.NewFunction(source, rel.NewExprPattern(rel.NewIdentExpr(source, names[i])), expr))
+	}
+	return expr
+}
+
+// checkSameScopeRebinding panics if node (a "let", "letm", "uniondef" or
+// "importAs" node, per kind) rebinds, within its own chain of
+// directly-sequential let-like statements, a name already bound earlier in
+// that chain. The chain extends through node's own binding(s) and,
+// transitively, through any let-like form that is its immediate body;
+// reaching any other kind of body (a lambda, a tuple element, ...) ends the
+// chain, where the usual shadowing rules apply.
+func (pc ParseContext) checkSameScopeRebinding(kind string, node ast.Branch) {
+	pc.checkChainRebinding(kind, node, false)
+}
+
+// checkImportAliasCollisionAhead is like checkSameScopeRebinding, but
+// unconditionally (regardless of StrictRebinding) checks only for a later
+// importAs in the chain whose alias collides with an earlier binding,
+// ignoring any other same-scope rebinding the chain may contain. compileLet,
+// compileLetMulti and compileUnionDef call this so an importAs's alias
+// colliding with one of their own names is always a compile error, the same
+// as when the importAs comes first (compileImportAs's own check walks
+// forward and already catches that direction unconditionally).
+func (pc ParseContext) checkImportAliasCollisionAhead(kind string, node ast.Branch) {
+	pc.checkChainRebinding(kind, node, true)
+}
+
+func (pc ParseContext) checkChainRebinding(kind string, node ast.Branch, importAsOnly bool) {
+	bound := map[string]parser.Scanner{}
+	for {
+		var names []string
+		var body ast.Node
+		switch kind {
+		case "let":
+			names = pc.compilePattern(node).Bindings()
+			body = node.Many(exprTag)[1]
+		case "letm":
+			for _, b := range node.Many("bindings") {
+				names = append(names, pc.compilePattern(b.(ast.Branch)).Bindings()...)
+			}
+			body = node.One(exprTag)
+		case "uniondef":
+			for _, ctor := range node.Many("ctor") {
+				names = append(names, ctor.(ast.Branch).One("ctorName").One("IDENT").One("").(ast.Leaf).Scanner().String())
+			}
+			body = node.One(exprTag)
+		case "importAs":
+			names = []string{node.One("alias").One("IDENT").One("").(ast.Leaf).Scanner().String()}
+			body = node.One(exprTag)
+		}
+		for _, name := range names {
+			if prev, dup := bound[name]; dup && (!importAsOnly || kind == "importAs") {
+				panic(fmt.Errorf(
+					"let: %q is already bound in this scope and cannot be rebound without a nested scope:\n%s\n%s",
+					name, prev.Context(parser.DefaultLimit), node.Scanner().Context(parser.DefaultLimit)))
+			}
+			bound[name] = node.Scanner()
+		}
+		nextKind, nextNode := nextChainLet(body)
+		if nextNode == nil {
+			return
+		}
+		kind, node = nextKind, nextNode
+	}
+}
+
+// nextChainLet looks through the (possibly parenthesized) precedence-level
+// indirection wrapping a let-like form's body expr and, if the body is
+// itself a let, letm, uniondef or importAs, returns its kind and node for
+// checkChainRebinding to
+// continue its same-scope chain walk. It returns ("", nil) otherwise.
+func nextChainLet(body ast.Node) (string, ast.Branch) {
+	b, ok := body.(ast.Branch)
+	if !ok {
+		return "", nil
+	}
+	// Use the same priority order as CompileExpr's own dispatch, so a
+	// branch offering both "expr" (an operand) and some other operator key
+	// (e.g. "tail_op" for a call) is recognized as that operator, not
+	// misread as a plain pass-through down to a deeper "expr".
+	name, c := which(b,
+		"amp", "arrow", "let", "letm", "uniondef", "importAs", "unop", "withop", "binop", "compare", "matches",
+		"rbinop", "if", "get", "tail_op", "postfix", "touch", "convert", "get", "rel", "set", "dict", "array", "bytes",
+		"embed", "op", "fn", "pkg", "tuple", "xstr", "IDENT", "STR", "NUM", "CHAR",
+		"cond", "iflet", "reduceWhile", "try", "debug", "hash", "bool", "charClass", "pad", "replace", "replaceLit", "trim", "parseNum", "now", "genid", "rand", "shuffle", "numtheory", "roundFn", "numHelper", "formatTime", "parseTime", "duration", "addDuration", "timeDiff", "env", "glob", "readFile", "write", "diff", "mergeDeep", "getPath", "setPath", "indexOf", "repeatStr", "caseConv", "displayWidth", "wordWrap", "dedent", "indent", "toBase", "fromBase", "bitCount", "checksum", "hmacSha256", "b64", "jsonDecode", "jsonEncode", "csvDecode", "xmlDecode", "render", "record", "approxEqual", "insertSorted", "memo", "interleave", "splitAt", exprTag,
+	)
+	switch name {
+	case "let", "letm", "uniondef", "importAs":
+		return name, c.(ast.One).Node.(ast.Branch)
+	case exprTag:
+		switch c := c.(type) {
+		case ast.One:
+			return nextChainLet(c.Node)
+		case ast.Many:
+			if len(c) == 1 {
+				return nextChainLet(c[0])
+			}
+		}
+	}
+	return "", nil
+}
+
+// literalElementKind classifies an array/set literal element's AST node by
+// the kind of literal it is (e.g. "number", "tuple"), unwrapping any
+// parenthesization, for StrictLiteralKinds homogeneity checking. ok is
+// false for anything computed dynamically (an IDENT other than true/false,
+// a call, an operator, ...), which is exempt from the check.
+func literalElementKind(node ast.Node) (kind string, ok bool) {
+	b, isBranch := node.(ast.Branch)
+	if !isBranch {
+		return "", false
+	}
+	// Same priority order as CompileExpr's own dispatch, so a branch
+	// offering both "expr" (an operand) and some other operator key isn't
+	// misread as a plain pass-through down to a deeper "expr".
+	name, c := which(b,
+		"amp", "arrow", "let", "letm", "uniondef", "importAs", "unop", "withop", "binop", "compare", "matches",
+		"rbinop", "if", "get", "tail_op", "postfix", "touch", "convert", "get", "rel", "set", "dict", "array", "bytes",
+		"embed", "op", "fn", "pkg", "tuple", "xstr", "IDENT", "STR", "NUM", "CHAR",
+		"cond", "iflet", "reduceWhile", "try", "debug", "hash", "bool", "charClass", "pad", "replace", "replaceLit", "trim", "parseNum", "now", "genid", "rand", "shuffle", "numtheory", "roundFn", "numHelper", "formatTime", "parseTime", "duration", "addDuration", "timeDiff", "env", "glob", "readFile", "write", "diff", "mergeDeep", "getPath", "setPath", "indexOf", "repeatStr", "caseConv", "displayWidth", "wordWrap", "dedent", "indent", "toBase", "fromBase", "bitCount", "checksum", "hmacSha256", "b64", "jsonDecode", "jsonEncode", "csvDecode", "xmlDecode", "render", "record", "approxEqual", "insertSorted", "memo", "interleave", "splitAt", exprTag,
+	)
+	switch name {
+	case "NUM":
+		return "number", true
+	case "STR":
+		return "string", true
+	case "CHAR":
+		return "char", true
+	case "tuple", "set", "array", "dict", "rel", "bytes":
+		return name, true
+	case "IDENT":
+		switch c.(ast.One).Node.One("").(ast.Leaf).Scanner().String() {
+		case "true", "false":
+			return "bool", true
+		}
+		return "", false
+	case exprTag:
+		switch c := c.(type) {
+		case ast.One:
+			return literalElementKind(c.Node)
+		case ast.Many:
+			if len(c) == 1 {
+				return literalElementKind(c[0])
+			}
+		}
+	}
+	return "", false
+}
+
+// checkLiteralKindHomogeneity emits a StrictLiteralKinds warning, naming
+// what (e.g. "array" or "set"), if elems mixes literal kinds, e.g. a
+// number beside a tuple -- usually a mistake in a data file. Dynamic
+// (non-literal) elements are skipped, and only the first mismatch found is
+// reported.
+func (pc ParseContext) checkLiteralKindHomogeneity(what string, elems []ast.Node) {
+	firstKind, firstPos := "", parser.Scanner{}
+	for _, elem := range elems {
+		kind, ok := literalElementKind(elem)
+		if !ok {
+			continue
+		}
+		if firstKind == "" {
+			firstKind, firstPos = kind, elem.Scanner()
+			continue
+		}
+		if kind != firstKind {
+			pos := elem.Scanner()
+			msg := fmt.Sprintf("%s literal mixes %s and %s elements:\n%s\n%s",
+				what, firstKind, kind,
+				firstPos.Context(parser.DefaultLimit), pos.Context(parser.DefaultLimit))
+			if pc.LiteralKindWarning != nil {
+				pc.LiteralKindWarning(msg, pos)
+			} else {
+				log.Error(context.Background(), errors.New(msg))
+			}
+			return
+		}
+	}
+}
+
+// checkUnionExhaustiveness emits a warning (or, under
+// StrictUnionExhaustiveness, a compile error) when a cond compiled
+// within a uniondef's own body (see compileUnionDef) switches on the
+// union's tag attr but omits one of its variants, with no catch-all
+// (bare identifier) pattern to cover the rest. Outside such a body,
+// pc.activeUnionVariants is nil and the check is skipped. A cond whose
+// patterns don't recognizably switch on any of this union's variants
+// (e.g. a cond with a numeric control var, unrelated to any union) is
+// also skipped, rather than being treated as handling none of them --
+// activeUnionVariants stays in scope for the union's whole lexical
+// remainder, so this check runs against every cond in that remainder,
+// not just ones that actually tag-match the union.
+func (pc ParseContext) checkUnionExhaustiveness(conditions []rel.Pattern, pos parser.Scanner) {
+	if pc.activeUnionVariants == nil {
+		return
+	}
+
+	variants := map[string]bool{}
+	for _, name := range pc.activeUnionVariants {
+		variants[name] = true
+	}
+
+	covered := map[string]bool{}
+	recognizedVariant := false
+	for _, condition := range conditions {
+		tag, isDefault, ok := unionCondTag(condition)
+		if !ok {
+			continue
+		}
+		if isDefault {
+			return
+		}
+		if !variants[tag] {
+			continue
+		}
+		recognizedVariant = true
+		covered[tag] = true
+	}
+	if !recognizedVariant {
+		return
+	}
+
+	var missing []string
+	for _, name := range pc.activeUnionVariants {
+		if !covered[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+
+	msg := fmt.Sprintf("cond doesn't handle union variant(s) %s, and has no default case:\n%s",
+		strings.Join(missing, ", "), pos.Context(parser.DefaultLimit))
+	if pc.StrictUnionExhaustiveness {
+		panic(errors.New(msg))
+	}
+	if pc.UnionExhaustivenessWarning != nil {
+		pc.UnionExhaustivenessWarning(msg, pos)
+	} else {
+		log.Error(context.Background(), errors.New(msg))
+	}
+}
+
+// unionCondTag recognises a compiled cond pattern as either a catch-all
+// -- a bare identifier, which rel.ExprPattern.Bind always matches by
+// binding the value to it -- or a `(tag: "CtorName", ...)` tuple pattern
+// whose tag attr is a literal string, returning ok=false for anything
+// else (a NUM, a nested pattern with no literal tag, ...), which is
+// exempt from the check, the same way literalElementKind ignores
+// non-literal elements: a missed warning beats a false one.
+func unionCondTag(pattern rel.Pattern) (tag string, isDefault, ok bool) {
+	switch p := pattern.(type) {
+	case rel.ExprPattern:
+		if _, is := p.Expr.(rel.IdentExpr); is {
+			return "", true, true
+		}
+	case rel.TuplePattern:
+		for _, attr := range p.Attrs() {
+			if attr.Name() != unionTagAttr {
+				continue
+			}
+			var attrExpr rel.Expr
+			switch ap := attr.Pattern().Pattern().(type) {
+			case rel.ExprPattern:
+				attrExpr = ap.Expr
+			case rel.ExprsPattern:
+				if len(ap.Exprs()) != 1 {
+					continue
+				}
+				attrExpr = ap.Exprs()[0]
+			default:
+				continue
+			}
+			value, is := rel.ExprAsValue(attrExpr)
+			if !is {
+				continue
+			}
+			set, is := value.(rel.Set)
+			if !is {
+				continue
+			}
+			str, is := rel.AsString(set)
+			if !is {
+				continue
+			}
+			return str.String(), false, true
+		}
+	}
+	return "", false, false
+}
+
+func (pc ParseContext) compileUnop(b ast.Branch, c ast.Children) rel.Expr {
+	ops := c.(ast.Many)
+	result := pc.CompileExpr(b.One(exprTag).(ast.Branch))
+	for i := len(ops) - 1; i >= 0; i-- {
+		op := ops[i].One("").(ast.Leaf).Scanner()
+		if pc.DisallowEval && op.String() == "*" {
+			panic(fmt.Errorf("dynamic eval not permitted: %s", op.String()))
+		}
+		f := unops[op.String()]
+		source, err := parser.MergeScanners(op, result.Source())
+		if err != nil {
+			// TODO: Figure out why some exprs don't have usable sources (could be native funcs).
+			source = op
+		}
+		result = f(source, result)
+	}
+	return result
+}
+
+// compileWithOp compiles a chain of `with`/`without`/`with [index] = value`
+// at the same precedence level, e.g. `a with b without c with [0] = d`.
+func (pc ParseContext) compileWithOp(b ast.Branch, c ast.Children) rel.Expr {
+	result := pc.CompileExpr(b.One(exprTag).(ast.Branch))
+	for _, withop := range c.(ast.Many) {
+		branch := withop.(ast.Branch)
+		if update := branch.One("arrayUpdate"); update != nil {
+			updateBranch := update.(ast.Branch)
+			index := pc.CompileExpr(updateBranch.One("index").(ast.Branch))
+			value := pc.CompileExpr(updateBranch.One("value").(ast.Branch))
+			source, err := parser.MergeScanners(updateBranch.Scanner(), result.Source(), value.Source())
+			if err != nil {
+				source = updateBranch.Scanner()
+			}
+			result = rel.NewArrayUpdateExpr(source, result, index, value)
+			continue
+		}
+		op := branch.One("op").(ast.Branch).One("").(ast.Leaf).Scanner()
+		value := pc.CompileExpr(branch.One("value").(ast.Branch))
+		source, err := parser.MergeScanners(op, result.Source(), value.Source())
+		if err != nil {
+			source = op
+		}
+		result = binops[op.String()](source, result, value)
+	}
+	return result
+}
+
+func (pc ParseContext) compileBinop(b ast.Branch, c ast.Children) rel.Expr {
+	ops := c.(ast.Many)
+	args := b.Many(exprTag)
+	exprs := make([]rel.Expr, len(args))
+	for i, arg := range args {
+		exprs[i] = pc.CompileExpr(arg.(ast.Branch))
+	}
+	exprs, ops = foldConcatRuns(exprs, ops)
+
+	result := exprs[0]
+	for i, rhs := range exprs[1:] {
+		op := ops[i].One("").(ast.Leaf).Scanner()
+		source, err := parser.MergeScanners(op, result.Source(), rhs.Source())
+		if err != nil {
+			// TODO: Figure out why some exprs don't have usable sources (could be native funcs).
+			source = op
+		}
+		if op.String() == "<&>" {
+			aNames, aOk := rel.StaticRelationAttrNames(result)
+			bNames, bOk := rel.StaticRelationAttrNames(rhs)
+			result = rel.NewJoinExprWithNames(source, result, rhs,
+				namesOrNil(aNames, aOk), namesOrNil(bNames, bOk))
+			continue
+		}
+		f := binops[op.String()]
+		result = f(source, result, rhs)
+	}
+	return result
+}
+
+// namesOrNil returns names' attribute names as a []string, or nil if ok is
+// false (the schema wasn't statically known).
+func namesOrNil(names rel.Names, ok bool) []string {
+	if !ok {
+		return nil
+	}
+	return names.OrderedNames()
+}
+
+// foldConcatRuns folds maximal runs of literal operands joined by "++" (e.g.
+// the "a" and "b" ++ "c" in `x ++ "a" ++ "b" ++ "c"`, but not x) into a
+// single FoldedExpr each, leaving any non-"++" joins and non-literal
+// operands untouched. This speeds up template-heavy code built from chains
+// of literal string/array concatenation, without changing evaluation order:
+// dynamic operands are still evaluated exactly where they appear.
+func foldConcatRuns(exprs []rel.Expr, ops ast.Many) ([]rel.Expr, ast.Many) {
+	foldedExprs := exprs[:1:1]
+	foldedOps := ops[:0:0]
+	runValue, runIsLiteral := rel.ExprAsValue(exprs[0])
+	for i, rhs := range exprs[1:] {
+		op := ops[i].One("").(ast.Leaf).Scanner()
+		rhsValue, rhsIsLiteral := rel.ExprAsValue(rhs)
+		if op.String() == "++" && runIsLiteral && rhsIsLiteral {
+			if lhsSet, ok := runValue.(rel.Set); ok {
+				if rhsSet, ok := rhsValue.(rel.Set); ok {
+					if merged, err := rel.Concatenate(lhsSet, rhsSet); err == nil {
+						lhs := foldedExprs[len(foldedExprs)-1]
+						source, err := parser.MergeScanners(op, lhs.Source(), rhs.Source())
+						if err != nil {
+							source = op
+						}
+						foldedExprs[len(foldedExprs)-1] = rel.NewFoldedExpr(rel.NewConcatExpr(source, lhs, rhs), merged)
+						runValue = merged
+						continue
+					}
+				}
+			}
+		}
+		foldedExprs = append(foldedExprs, rhs)
+		foldedOps = append(foldedOps, ops[i])
+		runValue, runIsLiteral = rhsValue, rhsIsLiteral
+	}
+	return foldedExprs, foldedOps
+}
+
+func (pc ParseContext) compileCompare(b ast.Branch, c ast.Children) rel.Expr {
+	args := b.Many(exprTag)
+	argExprs := make([]rel.Expr, 0, len(args))
+	comps := make([]rel.CompareFunc, 0, len(args))
+
+	ops := c.(ast.Many)
+	opStrs := make([]string, 0, len(ops))
+
+	argExprs = append(argExprs, pc.CompileExpr(args[0].(ast.Branch)))
+	for i, arg := range args[1:] {
+		op := ops[i].One("").(ast.Leaf).Scanner().String()
+
+		argExprs = append(argExprs, pc.CompileExpr(arg.(ast.Branch)))
+		comps = append(comps, compareOps[op])
+
+		opStrs = append(opStrs, op)
+	}
+	scanner, err := parser.MergeScanners(argExprs[0].Source(), argExprs[len(argExprs)-1].Source())
+	if err != nil {
+		panic(err)
+	}
+	compareExpr := rel.NewCompareExpr(scanner, argExprs, comps, opStrs)
+	if result, ok := foldCompare(argExprs, comps); ok {
+		return rel.NewFoldedExpr(compareExpr, result)
+	}
+	return compareExpr
+}
+
+// foldCompare evaluates a chain of comparisons at compile time when every
+// operand is a literal value (e.g. `2 < 3`, `"a" = "a"`, `1 <: {1, 2}`),
+// returning the resulting boolean and true. If any operand isn't a literal,
+// or a comparison errors (e.g. a `<:` rhs that isn't a set), it returns
+// false, false so the caller falls back to the regular CompareExpr.
+func foldCompare(argExprs []rel.Expr, comps []rel.CompareFunc) (rel.Value, bool) {
+	values := make([]rel.Value, len(argExprs))
+	for i, expr := range argExprs {
+		v, is := rel.ExprAsValue(expr)
+		if !is {
+			return nil, false
+		}
+		values[i] = v
+	}
+	lhs := values[0]
+	for i, rhs := range values[1:] {
+		sat, err := comps[i](lhs, rhs)
+		if err != nil {
+			return nil, false
+		}
+		if !sat {
+			return rel.False, true
+		}
+		lhs = rhs
+	}
+	return rel.True, true
+}
+
+func (pc ParseContext) compileRbinop(b ast.Branch, c ast.Children) rel.Expr {
+	ops := c.(ast.Many)
+	args := b[exprTag].(ast.Many)
+	result := pc.CompileExpr(args[len(args)-1].(ast.Branch))
+	for i := len(args) - 2; i >= 0; i-- {
+		op := ops[i].One("").(ast.Leaf).Scanner()
+		f, has := binops[op.String()]
+		if !has {
+			panic("rbinop %q not found")
+		}
+		result = f(op, pc.CompileExpr(args[i].(ast.Branch)), result)
+	}
+	return result
+}
+
+func (pc ParseContext) compileIf(b ast.Branch, c ast.Children) rel.Expr {
+	loggingOnce.Do(func() {
+		log.Error(context.Background(),
+			errors.New("operator if is deprecated and will be removed soon, please use operator cond instead. "+
+				"Operator cond sample: let a = cond {2 > 1: 1, 2 > 3: 2, _: 3}"))
+	})
+
+	result := pc.CompileExpr(b.One(exprTag).(ast.Branch))
+	source := result.Source()
+	for _, ifelse := range c.(ast.Many) {
+		t := pc.CompileExpr(ifelse.One("t").(ast.Branch))
+		var f rel.Expr = rel.None
+		if fNode := ifelse.One("f"); fNode != nil {
+			f = pc.CompileExpr(fNode.(ast.Branch))
+		}
+		result = rel.NewIfElseExpr(source, result, t, f)
+	}
+	return result
+}
+
+func (pc ParseContext) compileCond(c ast.Children) rel.Expr {
+	if controlVar := c.(ast.One).Node.(ast.Branch)["controlVar"]; controlVar != nil {
+		return pc.compileCondWithControlVar(c)
+	}
+	return pc.compileCondWithoutControlVar(c)
+}
+
+func (pc ParseContext) compileCondWithControlVar(c ast.Children) rel.Expr {
+	conditions := pc.compileCondElements(c.(ast.One).Node.(ast.Branch)["condition"].(ast.Many)...)
+	values := pc.compileCondExprs(c.(ast.One).Node.(ast.Branch)["value"].(ast.Many)...)
+
+	if len(conditions) != len(values) {
+		panic("mismatch between conditions and values")
+	}
+
+	conditionPairs := []rel.PatternExprPair{}
+	for i, condition := range conditions {
+		conditionPairs = append(conditionPairs, rel.NewPatternExprPair(condition, values[i]))
+	}
+
+	pc.checkUnionExhaustiveness(conditions, c.(ast.One).Node.Scanner())
+
+	controlVar := c.(ast.One).Node.(ast.Branch)["controlVar"]
+	return rel.NewCondPatternControlVarExpr(c.(ast.One).Node.Scanner(),
+		pc.CompileExpr(controlVar.(ast.One).Node.(ast.Branch)),
+		conditionPairs...)
+}
+
+func (pc ParseContext) compileCondElements(elements ...ast.Node) []rel.Pattern {
+	result := make([]rel.Pattern, 0, len(elements))
+	for _, element := range elements {
+		name, c := which(element.(ast.Branch), "pattern")
+		if c == nil {
+			panic(fmt.Errorf("misshapen node AST: %v", element.(ast.Branch)))
+		}
+
+		if name == "pattern" {
+			pattern := pc.compilePattern(element.(ast.Branch))
+			if pattern != nil {
+				result = append(result, pattern)
+			}
+		}
+	}
+
+	return result
+}
+
+func (pc ParseContext) compileCondWithoutControlVar(c ast.Children) rel.Expr {
+	var result rel.Expr
+	entryExprs := pc.compileDictEntryExprs(c.(ast.One).Node.(ast.Branch))
+	if entryExprs != nil {
+		// Generates type DictExpr always to make sure it is easy to do Eval, only process type DictExpr.
+		result = rel.NewDictExpr(c.(ast.One).Node.Scanner(), false, true, entryExprs...)
+	} else {
+		result = rel.NewDict(false)
+	}
+
+	// Note, the default case `_:expr` which can match anything is parsed to condition/value pairs by current syntax.
+	return rel.NewCondExpr(c.(ast.One).Node.Scanner(), result)
+}
+
+// compileIfLet compiles `if let PATTERN = EXPR { THEN } else { ELSE }` into
+// a pattern-matching cond over the control var: the then-branch sees the
+// pattern's bindings in scope, and the else-branch (defaulting to `None`)
+// runs when the pattern doesn't match.
+func (pc ParseContext) compileIfLet(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	pattern := pc.compilePattern(b.One("pattern").(ast.Branch))
+	controlVar := pc.CompileExpr(b.One("controlVar").(ast.Branch))
+	then := pc.CompileExpr(b.One("then").(ast.Branch))
+
+	pairs := []rel.PatternExprPair{rel.NewPatternExprPair(pattern, then)}
+	if f := b.One("f"); f != nil {
+		elseExpr := pc.CompileExpr(f.(ast.Branch))
+		pairs = append(pairs, rel.NewPatternExprPair(rel.NewExprPattern(rel.NewIdentExpr(b.Scanner(), "_")), elseExpr))
+	}
+
+	return rel.NewCondPatternControlVarExpr(b.Scanner(), controlVar, pairs...)
+}
+
+// compileReduceWhile compiles `reduce init while cond do step [cap cap]` into
+// a ReduceWhileExpr: an iterative loop over an accumulator, independent of
+// any Set, distinct from the ARROW-style `reduce`/`sum`/`max` family which
+// fold over a Set's elements.
+func (pc ParseContext) compileReduceWhile(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	init := pc.CompileExpr(b.One("init").(ast.Branch))
+	cond := pc.CompileExpr(b.One("cond").(ast.Branch))
+	step := pc.CompileExpr(b.One("step").(ast.Branch))
+
+	var cap rel.Expr
+	if capNode := b.One("cap"); capNode != nil {
+		cap = pc.CompileExpr(capNode.(ast.Branch))
+	}
+
+	return rel.NewReduceWhileExpr(b.Scanner(), init, cond, step, cap)
+}
+
+// compileTry compiles `try body catch kind handler`: handler is evaluated
+// in place of body iff body fails with an error of the named kind (see
+// rel.TryErrorKinds); any other error propagates unchanged.
+func (pc ParseContext) compileTry(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	body := pc.CompileExpr(b.One("body").(ast.Branch))
+	kind := b.One("kind").One("IDENT").One("").(ast.Leaf).Scanner().String()
+	handler := pc.CompileExpr(b.One("handler").(ast.Branch))
+
+	return rel.NewTryExpr(b.Scanner(), body, kind, handler)
+}
+
+// compileDebug compiles `debug(label, body)`: an identity tap that, at
+// evaluation, reports label and body's value to pc.DebugSink (if set) and
+// returns the value unchanged, so a pipeline can be inspected without
+// restructuring it.
+func (pc ParseContext) compileDebug(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	label := pc.CompileExpr(b.One("label").(ast.Branch))
+	body := pc.CompileExpr(b.One("body").(ast.Branch))
+
+	return rel.NewDebugExpr(b.Scanner(), label, body, pc.DebugSink)
+}
+
+// compileHash compiles `hash(value)`: value's stable structural hash.
+func (pc ParseContext) compileHash(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	value := pc.CompileExpr(b.One("value").(ast.Branch))
+
+	return rel.NewHashExpr(b.Scanner(), value)
+}
+
+// compileCoerceBool compiles `bool(value)`: value's canonical truthiness
+// coercion, made explicit.
+func (pc ParseContext) compileCoerceBool(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	value := pc.CompileExpr(b.One("value").(ast.Branch))
+
+	return rel.NewCoerceBoolExpr(b.Scanner(), value)
+}
+
+// compileCharClass compiles `isDigit(c)`/`isLetter(c)`/`isSpace(c)`.
+func (pc ParseContext) compileCharClass(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	class := b.One("dir").One("").Scanner().String()
+	value := pc.CompileExpr(b.One("c").(ast.Branch))
+
+	return rel.NewCharClassExpr(b.Scanner(), class, value)
+}
+
+// compilePad compiles `padleft(s, width, ch)`/`padright(s, width, ch)`. A ch
+// that's statically known to be more than one rune is a compile error rather
+// than a runtime one.
+func (pc ParseContext) compilePad(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	left := b.One("dir").One("").Scanner().String() == "padleft"
+	s := pc.CompileExpr(b.One("s").(ast.Branch))
+	width := pc.CompileExpr(b.One("width").(ast.Branch))
+	ch := pc.CompileExpr(b.One("ch").(ast.Branch))
+
+	if chVal, ok := rel.ExprAsValue(ch); ok {
+		if chSet, ok := chVal.(rel.Set); ok {
+			if chStr, ok := rel.AsString(chSet); ok {
+				if n := len([]rune(chStr.String())); n != 1 {
+					name := "padright"
+					if left {
+						name = "padleft"
+					}
+					panic(fmt.Errorf("%s: ch must be a single-rune string, not %q", name, chStr.String()))
+				}
+			}
+		}
+	}
+
+	return rel.NewPadExpr(b.Scanner(), left, s, width, ch)
+}
+
+// compileRegexReplace compiles `replace(s, pattern, repl)`. A pattern that's
+// statically known to be malformed is a compile error rather than a runtime
+// one.
+func (pc ParseContext) compileRegexReplace(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	s := pc.CompileExpr(b.One("s").(ast.Branch))
+	pattern := pc.CompileExpr(b.One("pat").(ast.Branch))
+	repl := pc.CompileExpr(b.One("repl").(ast.Branch))
+
+	expr, err := rel.NewRegexReplaceExpr(b.Scanner(), s, pattern, repl)
+	if err != nil {
+		panic(err)
+	}
+	return expr
+}
+
+// compileReplaceLit compiles `replaceLit(s, old, new)` or `replaceLit(s,
+// old, new, count)`. An old that's statically known to be empty is a
+// compile error rather than a runtime one.
+func (pc ParseContext) compileReplaceLit(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	s := pc.CompileExpr(b.One("s").(ast.Branch))
+	old := pc.CompileExpr(b.One("old").(ast.Branch))
+	repl := pc.CompileExpr(b.One("new").(ast.Branch))
+	var count rel.Expr
+	if n := b.One("count"); n != nil {
+		count = pc.CompileExpr(n.(ast.Branch))
+	}
+
+	if oldVal, ok := rel.ExprAsValue(old); ok {
+		if oldSet, ok := oldVal.(rel.Set); ok {
+			if oldStr, ok := rel.AsString(oldSet); ok {
+				if oldStr.String() == "" {
+					panic(fmt.Errorf("replaceLit: old must not be empty"))
+				}
+			}
+		}
+	}
+
+	return rel.NewReplaceAllLiteralExpr(b.Scanner(), s, old, repl, count)
+}
+
+// compileTrim compiles `trim(s, cutset)`, `trimLeft(s, cutset)`,
+// `trimRight(s, cutset)`, `trimPrefix(s, prefix)` and `trimSuffix(s, suffix)`.
+func (pc ParseContext) compileTrim(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	class := b.One("dir").One("").Scanner().String()
+	s := pc.CompileExpr(b.One("s").(ast.Branch))
+	arg := pc.CompileExpr(b.One("arg").(ast.Branch))
+
+	return rel.NewTrimExpr(b.Scanner(), class, s, arg)
+}
+
+// compileParseNum compiles `parseNum(s, base)`.
+func (pc ParseContext) compileParseNum(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	s := pc.CompileExpr(b.One("s").(ast.Branch))
+	base := pc.CompileExpr(b.One("base").(ast.Branch))
+
+	return rel.NewParseIntExpr(b.Scanner(), s, base)
+}
+
+// compileTimeNow compiles `now()`, failing with a compile error when
+// DisallowAmbientTime is set.
+func (pc ParseContext) compileTimeNow(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	if pc.DisallowAmbientTime {
+		panic(fmt.Errorf("ambient time not permitted: now()"))
+	}
+
+	return rel.NewTimeNowExpr(b.Scanner(), pc.Clock)
+}
+
+// compileGenID compiles `genid(seed)`, failing with a compile error when
+// DisallowAmbientRandomness is set.
+func (pc ParseContext) compileGenID(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	if pc.DisallowAmbientRandomness {
+		panic(fmt.Errorf("ambient randomness not permitted: genid()"))
+	}
+
+	seed := pc.CompileExpr(b.One("seed").(ast.Branch))
+
+	return rel.NewGenIDExpr(b.Scanner(), seed, pc.IDGenerator)
+}
+
+// compileRand compiles `rand(seed)`, failing with a compile error when
+// DisallowAmbientRandomness is set.
+func (pc ParseContext) compileRand(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	if pc.DisallowAmbientRandomness {
+		panic(fmt.Errorf("ambient randomness not permitted: rand()"))
+	}
+
+	seed := pc.CompileExpr(b.One("seed").(ast.Branch))
+
+	return rel.NewRandomExpr(b.Scanner(), seed, pc.RandSource)
+}
+
+// compileShuffle compiles `shuffle(arr, seed)`, failing with a compile error
+// when DisallowAmbientRandomness is set.
+func (pc ParseContext) compileShuffle(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	if pc.DisallowAmbientRandomness {
+		panic(fmt.Errorf("ambient randomness not permitted: shuffle()"))
+	}
+
+	arr := pc.CompileExpr(b.One("arr").(ast.Branch))
+	seed := pc.CompileExpr(b.One("seed").(ast.Branch))
+
+	return rel.NewShuffleExpr(b.Scanner(), arr, seed, pc.RandSource)
+}
+
+// compileNumTheory compiles `gcd(a, b)`/`lcm(a, b)`.
+func (pc ParseContext) compileNumTheory(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	class := b.One("dir").One("").Scanner().String()
+	a := pc.CompileExpr(b.One("a").(ast.Branch))
+	bb := pc.CompileExpr(b.One("b").(ast.Branch))
+
+	if class == "lcm" {
+		return rel.NewLCMExpr(b.Scanner(), a, bb)
+	}
+	return rel.NewGCDExpr(b.Scanner(), a, bb)
+}
+
+// compileRound compiles `round(x)`, `round(x, places)`, `floor(x)`,
+// `ceil(x)` and `truncate(x)`.
+func (pc ParseContext) compileRound(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	class := b.One("dir").One("").Scanner().String()
+	x := pc.CompileExpr(b.One("x").(ast.Branch))
+	var places rel.Expr
+	if p := b.One("places"); p != nil {
+		places = pc.CompileExpr(p.(ast.Branch))
+	}
+
+	return rel.NewRoundExpr(b.Scanner(), class, x, places)
+}
+
+// compileNumHelper compiles `abs(x)`, `sign(x)` and `pow10(x)`.
+func (pc ParseContext) compileNumHelper(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	class := b.One("dir").One("").Scanner().String()
+	x := pc.CompileExpr(b.One("x").(ast.Branch))
+
+	switch class {
+	case "sign":
+		return rel.NewSignExpr(b.Scanner(), x)
+	case "pow10":
+		return rel.NewPow10Expr(b.Scanner(), x)
+	default:
+		return rel.NewAbsExpr(b.Scanner(), x)
+	}
+}
+
+// compileFormatTime compiles `formatTime(t, layout)`.
+func (pc ParseContext) compileFormatTime(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	t := pc.CompileExpr(b.One("t").(ast.Branch))
+	layout := pc.CompileExpr(b.One("layout").(ast.Branch))
+
+	return rel.NewClockFormatExpr(b.Scanner(), t, layout)
+}
+
+// compileParseTime compiles `parseTime(s, layout)`.
+func (pc ParseContext) compileParseTime(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	s := pc.CompileExpr(b.One("s").(ast.Branch))
+	layout := pc.CompileExpr(b.One("layout").(ast.Branch))
+
+	return rel.NewClockParseExpr(b.Scanner(), s, layout)
+}
+
+// compileDuration compiles `duration(s)`.
+func (pc ParseContext) compileDuration(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	s := pc.CompileExpr(b.One("s").(ast.Branch))
+
+	return rel.NewDurationExpr(b.Scanner(), "duration", s, nil)
+}
+
+// compileAddDuration compiles `addDuration(t, d)`.
+func (pc ParseContext) compileAddDuration(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	t := pc.CompileExpr(b.One("t").(ast.Branch))
+	d := pc.CompileExpr(b.One("d").(ast.Branch))
+
+	return rel.NewDurationExpr(b.Scanner(), "addDuration", t, d)
+}
+
+// compileTimeDiff compiles `timeDiff(a, b)`.
+func (pc ParseContext) compileTimeDiff(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	a := pc.CompileExpr(b.One("a").(ast.Branch))
+	bb := pc.CompileExpr(b.One("b").(ast.Branch))
+
+	return rel.NewDurationExpr(b.Scanner(), "timeDiff", a, bb)
+}
+
+// compileEnv compiles `env(name, default)`, failing with a compile error
+// when DisallowAmbientEnv is set.
+func (pc ParseContext) compileEnv(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	if pc.DisallowAmbientEnv {
+		panic(fmt.Errorf("ambient environment access not permitted: env()"))
+	}
+
+	name := pc.CompileExpr(b.One("varName").(ast.Branch))
+
+	var def rel.Expr
+	if d := b.One("default"); d != nil {
+		def = pc.CompileExpr(d.(ast.Branch))
+	}
+
+	return rel.NewEnvExpr(b.Scanner(), name, def, pc.EnvLookup)
+}
+
+// compileGlob compiles `glob(pattern)`, failing with a compile error when
+// DisallowAmbientFS is set.
+func (pc ParseContext) compileGlob(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	if pc.DisallowAmbientFS {
+		panic(fmt.Errorf("ambient filesystem access not permitted: glob()"))
+	}
+
+	pattern := pc.CompileExpr(b.One("globPattern").(ast.Branch))
+
+	return rel.NewGlobExpr(b.Scanner(), pattern, pc.FS)
+}
+
+// compileReadFile compiles `readFile(path)` and `readFileStr(path)`, failing
+// with a compile error when DisallowAmbientFS is set.
+func (pc ParseContext) compileReadFile(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	class := b.One("dir").One("").Scanner().String()
+
+	if pc.DisallowAmbientFS {
+		panic(fmt.Errorf("ambient filesystem access not permitted: %s()", class))
+	}
+
+	path := pc.CompileExpr(b.One("path").(ast.Branch))
+
+	return rel.NewReadFileExpr(b.Scanner(), class, path, pc.ReadFS)
+}
+
+// compileWrite compiles `write(sink, value)`, failing with a compile error
+// if sink is not a recognized sink name.
+func (pc ParseContext) compileWrite(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	sink := b.One("sink").One("IDENT").One("").(ast.Leaf).Scanner().String()
+	w := pc.resolveSink(sink)
+	if w == nil {
+		panic(fmt.Errorf("unknown sink: %q", sink))
+	}
+
+	value := pc.CompileExpr(b.One("value").(ast.Branch))
+
+	return rel.NewWriteExpr(b.Scanner(), sink, w, value)
+}
+
+// compileDiff compiles `diff(a, b)`.
+func (pc ParseContext) compileDiff(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	a := pc.CompileExpr(b.One("a").(ast.Branch))
+	bb := pc.CompileExpr(b.One("b").(ast.Branch))
+
+	return rel.NewStructuralDiffExpr(b.Scanner(), a, bb)
+}
+
+// compileMergeDeep compiles `mergeDeep(a, b)`.
+func (pc ParseContext) compileMergeDeep(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	a := pc.CompileExpr(b.One("a").(ast.Branch))
+	bb := pc.CompileExpr(b.One("b").(ast.Branch))
+
+	return rel.NewMergeDeepExpr(b.Scanner(), a, bb)
+}
+
+// compileGetPath compiles `getPath(value, path)`.
+func (pc ParseContext) compileGetPath(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	value := pc.CompileExpr(b.One("value").(ast.Branch))
+	path := pc.CompileExpr(b.One("path").(ast.Branch))
+
+	return rel.NewPathGetExpr(b.Scanner(), value, path)
+}
+
+// compileSetPath compiles `setPath(value, path, v)`.
+func (pc ParseContext) compileSetPath(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	value := pc.CompileExpr(b.One("value").(ast.Branch))
+	path := pc.CompileExpr(b.One("path").(ast.Branch))
+	v := pc.CompileExpr(b.One("v").(ast.Branch))
+
+	return rel.NewPathSetExpr(b.Scanner(), value, path, v)
+}
+
+// compileIndexOf compiles `indexOf(a, v)`.
+func (pc ParseContext) compileIndexOf(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	a := pc.CompileExpr(b.One("a").(ast.Branch))
+	v := pc.CompileExpr(b.One("v").(ast.Branch))
+
+	return rel.NewIndexOfExpr(b.Scanner(), a, v)
+}
+
+// compileRepeatStr compiles `repeatStr(s, n)`.
+func (pc ParseContext) compileRepeatStr(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	s := pc.CompileExpr(b.One("s").(ast.Branch))
+	n := pc.CompileExpr(b.One("n").(ast.Branch))
+
+	return rel.NewRepeatStringExpr(b.Scanner(), s, n)
+}
+
+// compileCaseConv compiles `upper(s)`, `lower(s)` or `title(s)`.
+func (pc ParseContext) compileCaseConv(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
 
-	p := pc.compilePattern(c.(ast.One).Node.(ast.Branch))
-	rhs = rel.NewFunction(source, p, rhs)
+	class := b.One("dir").One("").Scanner().String()
+	s := pc.CompileExpr(b.One("s").(ast.Branch))
 
-	if c.(ast.One).Node.One("rec") != nil {
-		fix, fixt := FixFuncs()
-		name := p.(rel.ExprPattern).Expr
-		expr = rel.NewRecursionExpr(c.Scanner(), name, expr, fix, fixt)
+	switch class {
+	case "upper":
+		return rel.NewUpperExpr(b.Scanner(), s)
+	case "lower":
+		return rel.NewLowerExpr(b.Scanner(), s)
+	default:
+		return rel.NewTitleCaseExpr(b.Scanner(), s)
 	}
+}
 
-	return binops["->"](source, expr, rhs)
+// compileStringWidth compiles `displayWidth(s)`.
+func (pc ParseContext) compileStringWidth(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	s := pc.CompileExpr(b.One("s").(ast.Branch))
+
+	return rel.NewStringWidthExpr(b.Scanner(), s)
 }
 
-func (pc ParseContext) compileUnop(b ast.Branch, c ast.Children) rel.Expr {
-	ops := c.(ast.Many)
-	result := pc.CompileExpr(b.One(exprTag).(ast.Branch))
-	for i := len(ops) - 1; i >= 0; i-- {
-		op := ops[i].One("").(ast.Leaf).Scanner()
-		f := unops[op.String()]
-		source, err := parser.MergeScanners(op, result.Source())
-		if err != nil {
-			// TODO: Figure out why some exprs don't have usable sources (could be native funcs).
-			source = op
-		}
-		result = f(source, result)
+// compileWrap compiles `wordWrap(s, width)`.
+func (pc ParseContext) compileWrap(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	s := pc.CompileExpr(b.One("s").(ast.Branch))
+	width := pc.CompileExpr(b.One("width").(ast.Branch))
+
+	return rel.NewWrapExpr(b.Scanner(), s, width)
+}
+
+// compileDedent compiles `dedent(s)`.
+func (pc ParseContext) compileDedent(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	s := pc.CompileExpr(b.One("s").(ast.Branch))
+
+	return rel.NewDedentExpr(b.Scanner(), s)
+}
+
+// compileIndent compiles `indent(s, prefix)`/`indent(s, prefix, all)`.
+func (pc ParseContext) compileIndent(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	s := pc.CompileExpr(b.One("s").(ast.Branch))
+	prefix := pc.CompileExpr(b.One("prefix").(ast.Branch))
+	var all rel.Expr
+	if a := b.One("all"); a != nil {
+		all = pc.CompileExpr(a.(ast.Branch))
 	}
-	return result
+
+	return rel.NewIndentExpr(b.Scanner(), s, prefix, all)
 }
 
-func (pc ParseContext) compileBinop(b ast.Branch, c ast.Children) rel.Expr {
-	ops := c.(ast.Many)
-	args := b.Many(exprTag)
-	result := pc.CompileExpr(args[0].(ast.Branch))
-	for i, arg := range args[1:] {
-		op := ops[i].One("").(ast.Leaf).Scanner()
-		f := binops[op.String()]
-		rhs := pc.CompileExpr(arg.(ast.Branch))
-		source, err := parser.MergeScanners(op, result.Source(), rhs.Source())
-		if err != nil {
-			// TODO: Figure out why some exprs don't have usable sources (could be native funcs).
-			source = op
+// checkBaseLiteral panics with a compile error if base is statically known
+// to be a whole number outside [2, 36].
+func checkBaseLiteral(base rel.Expr, name string) {
+	if baseVal, ok := rel.ExprAsValue(base); ok {
+		if baseNum, ok := baseVal.(rel.Number); ok {
+			if n, whole := baseNum.Int(); !whole || n < 2 || n > 36 {
+				panic(fmt.Errorf("%s: base must be a whole number in [2, 36], not %v", name, baseNum))
+			}
 		}
-		result = f(source, result, rhs)
 	}
-	return result
 }
 
-func (pc ParseContext) compileCompare(b ast.Branch, c ast.Children) rel.Expr {
-	args := b.Many(exprTag)
-	argExprs := make([]rel.Expr, 0, len(args))
-	comps := make([]rel.CompareFunc, 0, len(args))
+// compileNumberFormatBase compiles `toBase(n, base)`. A base that's
+// statically known to be out of range is a compile error rather than a
+// runtime one.
+func (pc ParseContext) compileNumberFormatBase(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
 
-	ops := c.(ast.Many)
-	opStrs := make([]string, 0, len(ops))
+	n := pc.CompileExpr(b.One("n").(ast.Branch))
+	base := pc.CompileExpr(b.One("base").(ast.Branch))
+	checkBaseLiteral(base, "toBase")
 
-	argExprs = append(argExprs, pc.CompileExpr(args[0].(ast.Branch)))
-	for i, arg := range args[1:] {
-		op := ops[i].One("").(ast.Leaf).Scanner().String()
+	return rel.NewNumberFormatBaseExpr(b.Scanner(), n, base)
+}
 
-		argExprs = append(argExprs, pc.CompileExpr(arg.(ast.Branch)))
-		comps = append(comps, compareOps[op])
+// compileNumberParseBase compiles `fromBase(s, base)`. A base that's
+// statically known to be out of range is a compile error rather than a
+// runtime one.
+func (pc ParseContext) compileNumberParseBase(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
 
-		opStrs = append(opStrs, op)
-	}
-	scanner, err := parser.MergeScanners(argExprs[0].Source(), argExprs[len(argExprs)-1].Source())
-	if err != nil {
-		panic(err)
-	}
-	return rel.NewCompareExpr(scanner, argExprs, comps, opStrs)
+	s := pc.CompileExpr(b.One("s").(ast.Branch))
+	base := pc.CompileExpr(b.One("base").(ast.Branch))
+	checkBaseLiteral(base, "fromBase")
+
+	return rel.NewNumberParseBaseExpr(b.Scanner(), s, base)
 }
 
-func (pc ParseContext) compileRbinop(b ast.Branch, c ast.Children) rel.Expr {
-	ops := c.(ast.Many)
-	args := b[exprTag].(ast.Many)
-	result := pc.CompileExpr(args[len(args)-1].(ast.Branch))
-	for i := len(args) - 2; i >= 0; i-- {
-		op := ops[i].One("").(ast.Leaf).Scanner()
-		f, has := binops[op.String()]
-		if !has {
-			panic("rbinop %q not found")
-		}
-		result = f(op, pc.CompileExpr(args[i].(ast.Branch)), result)
+// compileBitCount compiles `popcount(n)`/`leadingZeros(n)`/
+// `trailingZeros(n)`.
+func (pc ParseContext) compileBitCount(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	class := b.One("dir").One("").Scanner().String()
+	n := pc.CompileExpr(b.One("n").(ast.Branch))
+
+	switch class {
+	case "popcount":
+		return rel.NewBitCountExpr(b.Scanner(), n)
+	case "leadingZeros":
+		return rel.NewLeadingZerosExpr(b.Scanner(), n)
+	default:
+		return rel.NewTrailingZerosExpr(b.Scanner(), n)
 	}
-	return result
 }
 
-func (pc ParseContext) compileIf(b ast.Branch, c ast.Children) rel.Expr {
-	loggingOnce.Do(func() {
-		log.Error(context.Background(),
-			errors.New("operator if is deprecated and will be removed soon, please use operator cond instead. "+
-				"Operator cond sample: let a = cond {2 > 1: 1, 2 > 3: 2, _: 3}"))
-	})
+// compileChecksum compiles `sha256(bytes)`/`md5(bytes)`/`crc32(bytes)`.
+func (pc ParseContext) compileChecksum(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
 
-	result := pc.CompileExpr(b.One(exprTag).(ast.Branch))
-	source := result.Source()
-	for _, ifelse := range c.(ast.Many) {
-		t := pc.CompileExpr(ifelse.One("t").(ast.Branch))
-		var f rel.Expr = rel.None
-		if fNode := ifelse.One("f"); fNode != nil {
-			f = pc.CompileExpr(fNode.(ast.Branch))
-		}
-		result = rel.NewIfElseExpr(source, result, t, f)
+	algorithm := b.One("dir").One("").Scanner().String()
+	bytes := pc.CompileExpr(b.One("bytes").(ast.Branch))
+
+	switch algorithm {
+	case "sha256":
+		return rel.NewChecksumExpr(b.Scanner(), bytes)
+	case "md5":
+		return rel.NewMD5ChecksumExpr(b.Scanner(), bytes)
+	default:
+		return rel.NewCRC32ChecksumExpr(b.Scanner(), bytes)
 	}
-	return result
 }
 
-func (pc ParseContext) compileCond(c ast.Children) rel.Expr {
-	if controlVar := c.(ast.One).Node.(ast.Branch)["controlVar"]; controlVar != nil {
-		return pc.compileCondWithControlVar(c)
-	}
-	return pc.compileCondWithoutControlVar(c)
+// compileHmac compiles `hmacSha256(key, message)`.
+func (pc ParseContext) compileHmac(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	key := pc.CompileExpr(b.One("key").(ast.Branch))
+	message := pc.CompileExpr(b.One("message").(ast.Branch))
+
+	return rel.NewHmacExpr(b.Scanner(), key, message)
 }
 
-func (pc ParseContext) compileCondWithControlVar(c ast.Children) rel.Expr {
-	conditions := pc.compileCondElements(c.(ast.One).Node.(ast.Branch)["condition"].(ast.Many)...)
-	values := pc.compileCondExprs(c.(ast.One).Node.(ast.Branch)["value"].(ast.Many)...)
+// compileBase64 compiles `base64encode(value, urlSafe)`/
+// `base64decode(value, urlSafe)`.
+func (pc ParseContext) compileBase64(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
 
-	if len(conditions) != len(values) {
-		panic("mismatch between conditions and values")
+	decode := b.One("dir").One("").Scanner().String() == "base64decode"
+	value := pc.CompileExpr(b.One("value").(ast.Branch))
+	urlSafe := pc.CompileExpr(b.One("urlSafe").(ast.Branch))
+
+	return rel.NewBase64Expr(b.Scanner(), decode, value, urlSafe)
+}
+
+// compileJSONDecode compiles `jsonDecode(s)`.
+func (pc ParseContext) compileJSONDecode(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	s := pc.CompileExpr(b.One("s").(ast.Branch))
+
+	return rel.NewJSONDecodeExpr(b.Scanner(), s)
+}
+
+// compileJSONEncode compiles `jsonEncode(value)`.
+func (pc ParseContext) compileJSONEncode(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	value := pc.CompileExpr(b.One("value").(ast.Branch))
+
+	return rel.NewJSONEncodeExpr(b.Scanner(), value)
+}
+
+// compileCSVDecode compiles `csvDecode(s, header, delimiter)`.
+func (pc ParseContext) compileCSVDecode(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	s := pc.CompileExpr(b.One("s").(ast.Branch))
+	header := pc.CompileExpr(b.One("header").(ast.Branch))
+	delimiter := pc.CompileExpr(b.One("delimiter").(ast.Branch))
+
+	return rel.NewCSVDecodeExpr(b.Scanner(), s, header, delimiter)
+}
+
+// compileXMLDecode compiles `xmlDecode(s)`.
+func (pc ParseContext) compileXMLDecode(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	s := pc.CompileExpr(b.One("s").(ast.Branch))
+
+	return rel.NewXMLDecodeExpr(b.Scanner(), s)
+}
+
+// compileTemplate compiles `render(template, value)`.
+func (pc ParseContext) compileTemplate(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	template := pc.CompileExpr(b.One("template").(ast.Branch))
+	value := pc.CompileExpr(b.One("value").(ast.Branch))
+
+	return rel.NewTemplateExpr(b.Scanner(), template, value)
+}
+
+// compileRecord compiles `record(x, y, ...)`, sugar for
+// `\(x, y, ...) (x: x, y: y, ...)`: a constructor function that accepts a
+// tuple shaped exactly like its field list and returns it unchanged.
+func (pc ParseContext) compileRecord(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+	source := b.Scanner()
+
+	var names []string
+	for _, name := range b.Many("fields") {
+		names = append(names, name.(ast.Branch).One("IDENT").One("").(ast.Leaf).Scanner().String())
 	}
 
-	conditionPairs := []rel.PatternExprPair{}
-	for i, condition := range conditions {
-		conditionPairs = append(conditionPairs, rel.NewPatternExprPair(condition, values[i]))
+	patternAttrs := make([]rel.TuplePatternAttr, len(names))
+	tupleAttrs := make([]rel.AttrExpr, len(names))
+	for i, name := range names {
+		patternAttrs[i] = rel.NewTuplePatternAttr(
+			name, rel.NewFallbackPattern(rel.NewExprPattern(rel.NewIdentExpr(source, name)), nil))
+		attr, err := rel.NewAttrExpr(source, name, rel.NewIdentExpr(source, name))
+		if err != nil {
+			panic(err)
+		}
+		tupleAttrs[i] = attr
 	}
 
-	controlVar := c.(ast.One).Node.(ast.Branch)["controlVar"]
-	return rel.NewCondPatternControlVarExpr(c.(ast.One).Node.Scanner(),
-		pc.CompileExpr(controlVar.(ast.One).Node.(ast.Branch)),
-		conditionPairs...)
+	return rel.NewFunction(source, rel.NewTuplePattern(patternAttrs...), rel.NewTupleExpr(source, tupleAttrs...))
 }
 
-func (pc ParseContext) compileCondElements(elements ...ast.Node) []rel.Pattern {
-	result := make([]rel.Pattern, 0, len(elements))
-	for _, element := range elements {
-		name, c := which(element.(ast.Branch), "pattern")
-		if c == nil {
-			panic(fmt.Errorf("misshapen node AST: %v", element.(ast.Branch)))
-		}
+// compileApproxEqual compiles `approxEqual(a, b, eps)`.
+func (pc ParseContext) compileApproxEqual(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
 
-		if name == "pattern" {
-			pattern := pc.compilePattern(element.(ast.Branch))
-			if pattern != nil {
-				result = append(result, pattern)
-			}
-		}
+	a := pc.CompileExpr(b.One("a").(ast.Branch))
+	other := pc.CompileExpr(b.One("b").(ast.Branch))
+	eps := pc.CompileExpr(b.One("eps").(ast.Branch))
+
+	return rel.NewDeepEqualExpr(b.Scanner(), a, other, eps)
+}
+
+// compileInsertSorted compiles `insertSorted(arr, v)` or
+// `insertSorted(arr, v, key)`.
+func (pc ParseContext) compileInsertSorted(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	arr := pc.CompileExpr(b.One("arr").(ast.Branch))
+	v := pc.CompileExpr(b.One("v").(ast.Branch))
+	var key rel.Expr
+	if k := b.One("key"); k != nil {
+		key = pc.CompileExpr(k.(ast.Branch))
 	}
 
-	return result
+	return rel.NewSortedInsertExpr(b.Scanner(), arr, v, key)
 }
 
-func (pc ParseContext) compileCondWithoutControlVar(c ast.Children) rel.Expr {
-	var result rel.Expr
-	entryExprs := pc.compileDictEntryExprs(c.(ast.One).Node.(ast.Branch))
-	if entryExprs != nil {
-		// Generates type DictExpr always to make sure it is easy to do Eval, only process type DictExpr.
-		result = rel.NewDictExpr(c.(ast.One).Node.Scanner(), false, true, entryExprs...)
-	} else {
-		result = rel.NewDict(false)
+// compileMemo compiles `memo(body)`.
+func (pc ParseContext) compileMemo(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	body := pc.CompileExpr(b.One("body").(ast.Branch))
+
+	return rel.NewMemoGraphExpr(b.Scanner(), body)
+}
+
+// compileInterleave compiles `interleave(a, b, ...)`.
+func (pc ParseContext) compileInterleave(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	arrs := b.Many("arrs")
+	exprs := make([]rel.Expr, len(arrs))
+	for i, a := range arrs {
+		exprs[i] = pc.CompileExpr(a.(ast.Branch))
 	}
 
-	// Note, the default case `_:expr` which can match anything is parsed to condition/value pairs by current syntax.
-	return rel.NewCondExpr(c.(ast.One).Node.Scanner(), result)
+	return rel.NewInterleaveExpr(b.Scanner(), exprs...)
+}
+
+// compileSplitAt compiles `splitAt(arr, n)`.
+func (pc ParseContext) compileSplitAt(c ast.Children) rel.Expr {
+	b := c.(ast.One).Node.(ast.Branch)
+
+	arr := pc.CompileExpr(b.One("arr").(ast.Branch))
+	n := pc.CompileExpr(b.One("n").(ast.Branch))
+
+	return rel.NewSplitAtExpr(b.Scanner(), arr, n)
+}
+
+// compileMatches compiles `value ~ pattern`, reusing compilePattern to test
+// whether pattern structurally matches value, discarding any bindings it
+// would introduce.
+func (pc ParseContext) compileMatches(b ast.Branch) rel.Expr {
+	value := pc.CompileExpr(b.One(exprTag).(ast.Branch))
+	pattern := pc.compilePattern(b.One("matches").(ast.Branch).One("pattern").(ast.Branch))
+	return rel.NewMatchesExpr(b.Scanner(), value, pattern)
 }
 
 func (pc ParseContext) compilePostfixAndTouch(b ast.Branch, c ast.Children) rel.Expr {
 	if _, has := b["touch"]; has {
 		panic("unfinished")
 	}
-	switch c.Scanner().String() {
-	case "count":
-		return rel.NewCountExpr(b.Scanner(), pc.CompileExpr(b.One(exprTag).(ast.Branch)))
-	case "single":
-		return rel.NewSingleExpr(b.Scanner(), pc.CompileExpr(b.One(exprTag).(ast.Branch)))
-	default:
-		panic("wat?")
+
+	result := pc.CompileExpr(b.One(exprTag).(ast.Branch))
+	if postfix := b.One("postfix"); postfix != nil {
+		switch postfix.Scanner().String() {
+		case "count":
+			result = rel.NewCountExpr(b.Scanner(), result)
+		case "single":
+			result = rel.NewSingleExpr(b.Scanner(), result)
+		default:
+			panic("wat?")
+		}
+	}
+	if convert := b.One("convert"); convert != nil {
+		result = pc.compileConvert(result, convert.(ast.Branch))
 	}
+	return result
 
 	// touch -> ("->*" ("&"? IDENT | STR))+ "(" expr:"," ","? ")";
 	// result := p.parseExpr(b.One(exprTag).(ast.Branch))
 }
 
+// compileConvert compiles the `expr::target` postfix shorthand (e.g.
+// `x::string`) into a call to the matching `//convert.target` std function.
+// The target must be one of the supported conversion kinds; anything else
+// is a compile error rather than a runtime one.
+func (pc ParseContext) compileConvert(base rel.Expr, convert ast.Branch) rel.Expr {
+	scanner := convert.Scanner()
+	target := convert.One("target").One("CONVERT_TARGET").One("").(ast.Leaf).Scanner().String()
+	if _, ok := convertTargets[target]; !ok {
+		panic(fmt.Errorf("unsupported conversion target: %q", target))
+	}
+	fn := NewPackageExpr(scanner, rel.NewDotExpr(scanner, rel.NewDotExpr(scanner, rel.NewIdentExpr(scanner, "//"), "convert"), target))
+	return rel.NewCallExpr(scanner, fn, base)
+}
+
 func (pc ParseContext) compileCallGet(b ast.Branch) rel.Expr {
 	var result rel.Expr
 	if expr := b.One(exprTag); expr != nil {
@@ -540,13 +2100,27 @@ func (pc ParseContext) compileCallGet(b ast.Branch) rel.Expr {
 func (pc ParseContext) compileTail(base rel.Expr, tail ast.Node) rel.Expr {
 	if tail != nil {
 		if call := tail.One("call"); call != nil {
-			args := call.Many("arg")
-			exprs := make([]ast.Node, 0, len(args))
-			for _, arg := range args {
-				exprs = append(exprs, arg.One(exprTag))
-			}
-			for _, arg := range pc.compileExprs(exprs...) {
-				base = rel.NewCallExpr(handleAccessScanners(base.Source(), call.Scanner()), base, arg)
+			for _, arg := range call.Many("arg") {
+				argBranch := arg.(ast.Branch)
+				scanner := handleAccessScanners(base.Source(), call.Scanner())
+				if len(argBranch.Many("")) > 0 {
+					// A literal ":" was present, so this is a slice `(lo:hi:step)`,
+					// with lo, hi and step all optional.
+					var lo, hi, step rel.Expr
+					if e := argBranch.One("expr"); e != nil {
+						lo = pc.CompileExpr(e.(ast.Branch))
+					}
+					if e := argBranch.One("end"); e != nil {
+						hi = pc.CompileExpr(e.(ast.Branch))
+					}
+					if e := argBranch.One("step"); e != nil {
+						step = pc.CompileExpr(e.(ast.Branch))
+					}
+					base = rel.NewSliceExpr(scanner, base, lo, hi, step)
+				} else {
+					arg := pc.CompileExpr(argBranch.One(exprTag).(ast.Branch))
+					base = rel.NewCallExpr(scanner, base, arg)
+				}
 			}
 		}
 		base = pc.compileGet(base, tail.One("get"))
@@ -601,8 +2175,20 @@ func (pc ParseContext) compileTailFunc(tail ast.Node) rel.SafeTailCallback {
 	panic("no tail")
 }
 
+// compileGet compiles a `.name` access. The grammar also accepts a `.&name`
+// form (e.g. for parity with the touch grammar's reference marker), but
+// since arrai values are immutable there is no reference semantics to give
+// it: it compiles identically to plain `.name` access.
 func (pc ParseContext) compileGet(base rel.Expr, get ast.Node) rel.Expr {
 	if get != nil {
+		if renames := get.One("renames"); renames != nil {
+			pairs := parseRenames(renames.(ast.Branch))
+			return rel.NewTupleProjectRenameExpr(
+				handleAccessScanners(base.Source(), renames.Scanner()),
+				base, pairs,
+			)
+		}
+
 		if names := get.One("names"); names != nil {
 			inverse := get.One("") != nil
 			attrs := parseNames(names.(ast.Branch))
@@ -662,7 +2248,8 @@ func (pc ParseContext) compileSafeTails(base rel.Expr, tail ast.Node) rel.Expr {
 			}
 		}
 
-		return rel.NewSafeTailExpr(tail.Scanner(), fallback, base, exprStates)
+		noneGuard := len(tail.One("sep").Scanner().String()) > 1
+		return rel.NewSafeTailExpr(tail.Scanner(), fallback, base, exprStates, noneGuard)
 	}
 	//TODO: panic?
 	return base
@@ -684,12 +2271,23 @@ func handleAccessScanners(base, access parser.Scanner) parser.Scanner {
 }
 
 func (pc ParseContext) compileRelation(b ast.Branch, c ast.Children) rel.Expr {
-	names := parseNames(c.(ast.One).Node.(ast.Branch)["names"].(ast.One).Node.(ast.Branch))
-	tuples := c.(ast.One).Node.(ast.Branch)["tuple"].(ast.Many)
+	relBranch := c.(ast.One).Node.(ast.Branch)
+	var tuples ast.Many
+	if tuplesNode, has := relBranch["tuple"]; has {
+		tuples = tuplesNode.(ast.Many)
+	}
 	tupleExprs := make([][]rel.Expr, 0, len(tuples))
 	for _, tuple := range tuples {
 		tupleExprs = append(tupleExprs, pc.compileExprs(tuple.(ast.Branch)["v"].(ast.Many)...))
 	}
+
+	var names []string
+	if namesNode, has := relBranch["names"]; has {
+		names = parseNames(namesNode.(ast.One).Node.(ast.Branch))
+	} else if len(tupleExprs) > 0 {
+		names = inferAttrNames(tupleExprs[0])
+	}
+
 	result, err := rel.NewRelationExpr(
 		delimsScanner(b),
 		names,
@@ -701,10 +2299,34 @@ func (pc ParseContext) compileRelation(b ast.Branch, c ast.Children) rel.Expr {
 	return result
 }
 
+// inferAttrNames infers column names for a relation literal whose `|names|`
+// header was omitted, the same way compileTuple infers unnamed attr names:
+// each value must either be a bare identifier or end in a `.name` access.
+func inferAttrNames(exprs []rel.Expr) []string {
+	names := make([]string, 0, len(exprs))
+	for _, v := range exprs {
+		switch v := v.(type) {
+		case *rel.DotExpr:
+			names = append(names, v.Attr())
+		case rel.IdentExpr:
+			names = append(names, v.Ident())
+		default:
+			panic(fmt.Errorf(
+				"relation literal missing |names|: column name must be inferred from an ident or .name "+
+					"expression, got %T(%[1]v)", v))
+		}
+	}
+	return names
+}
+
 func (pc ParseContext) compileSet(b ast.Branch, c ast.Children) rel.Expr {
 	scanner := delimsScanner(b)
 	if elts := c.(ast.One).Node.(ast.Branch)["elt"]; elts != nil {
-		return rel.NewSetExpr(scanner, pc.compileExprs(elts.(ast.Many)...)...)
+		nodes := elts.(ast.Many)
+		if pc.StrictLiteralKinds {
+			pc.checkLiteralKindHomogeneity("set", nodes)
+		}
+		return rel.NewSetExpr(scanner, pc.compileExprs(nodes...)...)
 	}
 	return rel.NewLiteralExpr(scanner, rel.NewSet())
 }
@@ -736,6 +2358,9 @@ func (pc ParseContext) compileDictEntryExprs(b ast.Branch) []rel.DictEntryTupleE
 
 func (pc ParseContext) compileArray(b ast.Branch, c ast.Children) rel.Expr {
 	scanner := delimsScanner(b)
+	if pc.StrictLiteralKinds {
+		pc.checkLiteralKindHomogeneity("array", sparseItemNodes(c))
+	}
 	if exprs := pc.compileSparseItems(c); len(exprs) > 0 {
 		return rel.NewArrayExpr(scanner, exprs...)
 	}
@@ -758,6 +2383,28 @@ func (pc ParseContext) compileExprs(exprs ...ast.Node) []rel.Expr {
 	return result
 }
 
+// sparseItemNodes returns the non-empty element ast.Nodes of an array
+// literal's %!sparse_sequence items, in the order compileSparseItems
+// compiles them, with elided "empty" sparse slots (e.g. the gap in
+// `[a, , b]`) omitted.
+func sparseItemNodes(c ast.Children) []ast.Node {
+	var nodes []ast.Node
+	if firstItem := c.(ast.One).Node.One("first_item"); firstItem != nil {
+		nodes = []ast.Node{firstItem}
+		if items := c.(ast.One).Node.Many("item"); items != nil {
+			nodes = append(nodes, items...)
+		}
+	}
+	result := make([]ast.Node, 0, len(nodes))
+	for _, node := range nodes {
+		if node.One("empty") != nil {
+			continue
+		}
+		result = append(result, node)
+	}
+	return result
+}
+
 func (pc ParseContext) compileSparseItems(c ast.Children) []rel.Expr {
 	var nodes []ast.Node
 	if firstItem := c.(ast.One).Node.One("first_item"); firstItem != nil {
@@ -816,7 +2463,18 @@ func (pc ParseContext) compileCondExprs(exprs ...ast.Node) []rel.Expr {
 func (pc ParseContext) compileFunction(b ast.Branch) rel.Expr {
 	p := pc.compilePattern(b)
 	expr := pc.CompileExpr(b.One(exprTag).(ast.Branch))
-	return rel.NewFunction(b.Scanner(), p, expr)
+	scanner := b.Scanner()
+	if pc.AttachFunctionIDs {
+		return rel.NewFunctionWithID(scanner, p, expr, functionID(scanner.String()))
+	}
+	return rel.NewFunction(scanner, p, expr)
+}
+
+// functionID returns a deterministic identity for a function's source span,
+// stable across compilations given identical src.
+func functionID(src string) string {
+	sum := sha256.Sum256([]byte(src))
+	return hex.EncodeToString(sum[:])
 }
 
 func (pc ParseContext) compileMacro(b ast.Branch) rel.Expr {
@@ -830,6 +2488,14 @@ func (pc ParseContext) compileMacro(b ast.Branch) rel.Expr {
 func (pc ParseContext) compilePackage(b ast.Branch, c ast.Children) rel.Expr {
 	imp := b.One("import").Scanner()
 	pkg := c.(ast.One).Node.(ast.Branch)
+	return pc.compilePkgRef(imp, pkg)
+}
+
+// compilePkgRef compiles a `//pkg` reference's pkg alternation (shared by
+// the inline `//pkg` atom and `import //pkg as alias` below) into the Expr
+// that evaluates to that package. imp is the scanner for the `//` token,
+// used only to source the resulting expr.
+func (pc ParseContext) compilePkgRef(imp parser.Scanner, pkg ast.Branch) rel.Expr {
 	if std, has := pkg["std"]; has {
 		ident := std.(ast.One).Node.One("IDENT").One("")
 		pkgName := ident.(ast.Leaf).Scanner()
@@ -859,13 +2525,38 @@ func (pc ParseContext) compilePackage(b ast.Branch, c ast.Children) rel.Expr {
 	panic("malformed package AST")
 }
 
+// compileImportAs compiles `import //pkg as alias; body`, binding alias to
+// the package the same way `//pkg` would compile inline, for body to refer
+// to by its short name instead of repeating the full path. alias rebinding
+// a name already bound earlier in the same chain of directly-sequential
+// let-like statements is always a compile error, unlike the general
+// same-scope rebinding check, which only fires under StrictRebinding.
+func (pc ParseContext) compileImportAs(c ast.Children) rel.Expr {
+	node := c.(ast.One).Node.(ast.Branch)
+	pc.checkSameScopeRebinding("importAs", node)
+	source := c.Scanner()
+
+	imp := node.One("slashes").Scanner()
+	pkgExpr := pc.compilePkgRef(imp, node.One("pkg").(ast.Branch))
+	alias := node.One("alias").One("IDENT").One("").(ast.Leaf).Scanner().String()
+	body := pc.CompileExpr(node.One(exprTag).(ast.Branch))
+
+	return binops["->"](source, pkgExpr, rel.NewFunction(source, rel.NewExprPattern(rel.NewIdentExpr(source, alias)), body))
+}
+
 func (pc ParseContext) compileTuple(b ast.Branch, c ast.Children) rel.Expr {
 	scanner := delimsScanner(b)
 	if pairs := c.(ast.One).Node.Many("pairs"); pairs != nil {
 		attrs := make([]rel.AttrExpr, 0, len(pairs))
 		for _, pair := range pairs {
-			var k string
 			v := pc.CompileExpr(pair.One("v").(ast.Branch))
+			scanner := pair.One("v").(ast.Branch).Scanner()
+			if computed := pair.One("computed"); computed != nil {
+				nameExpr := pc.CompileExpr(computed.(ast.Branch).One(exprTag).(ast.Branch))
+				attrs = append(attrs, rel.NewComputedAttrExpr(scanner, nameExpr, v))
+				continue
+			}
+			var k string
 			if name := pair.One("name"); name != nil {
 				k = parseName(name.(ast.Branch))
 			} else {
@@ -878,7 +2569,6 @@ func (pc ParseContext) compileTuple(b ast.Branch, c ast.Children) rel.Expr {
 					panic(fmt.Errorf("unnamed attr expression must be name or end in .name: %T(%[1]v)", v))
 				}
 			}
-			scanner := pair.One("v").(ast.Branch).Scanner()
 			if pair.One("rec") != nil {
 				fix, fixt := FixFuncs()
 				v = rel.NewRecursionExpr(
@@ -922,7 +2612,13 @@ func (pc ParseContext) compileIdent(c ast.Children) rel.Expr {
 
 func (pc ParseContext) compileString(c ast.Children) rel.Expr {
 	scanner := c.(ast.One).Node.One("").Scanner()
-	return rel.NewLiteralExpr(scanner, rel.NewString([]rune(parseArraiString(scanner.String()))))
+	s := parseArraiString(scanner.String())
+	if pc.StringLiteralHook != nil {
+		if expr := pc.StringLiteralHook(s, scanner); expr != nil {
+			return expr
+		}
+	}
+	return rel.NewLiteralExpr(scanner, rel.NewString([]rune(s)))
 }
 
 func (pc ParseContext) compileNumber(c ast.Children) rel.Expr {
@@ -995,47 +2691,61 @@ var unops = map[string]unOpFunc{
 type binOpFunc func(scanner parser.Scanner, a, b rel.Expr) rel.Expr
 
 var binops = map[string]binOpFunc{
-	"->":      rel.NewArrowExpr,
-	"=>":      rel.NewDArrowExpr,
-	">>":      rel.NewSeqArrowExpr(false),
-	">>>":     rel.NewSeqArrowExpr(true),
-	":>":      rel.NewTupleMapExpr,
-	"orderby": rel.NewOrderByExpr,
-	"order":   rel.NewOrderExpr,
-	"rank":    rel.NewRankExpr,
-	"where":   rel.NewWhereExpr,
-	"sum":     rel.NewSumExpr,
-	"max":     rel.NewMaxExpr,
-	"mean":    rel.NewMeanExpr,
-	"median":  rel.NewMedianExpr,
-	"min":     rel.NewMinExpr,
-	"with":    rel.NewWithExpr,
-	"without": rel.NewWithoutExpr,
-	"&&":      rel.NewAndExpr,
-	"||":      rel.NewOrExpr,
-	"+":       rel.NewAddExpr,
-	"-":       rel.NewSubExpr,
-	"++":      rel.NewConcatExpr,
-	"&~":      rel.NewDiffExpr,
-	"~~":      rel.NewSymmDiffExpr,
-	"&":       rel.NewIntersectExpr,
-	"|":       rel.NewUnionExpr,
-	"<&>":     rel.NewJoinExpr,
-	"<->":     rel.NewComposeExpr,
-	"-&-":     rel.NewJoinCommonExpr,
-	"---":     rel.NewJoinExistsExpr,
-	"-&>":     rel.NewRightMatchExpr,
-	"<&-":     rel.NewLeftMatchExpr,
-	"-->":     rel.NewRightResidueExpr,
-	"<--":     rel.NewLeftResidueExpr,
-	"*":       rel.NewMulExpr,
-	"/":       rel.NewDivExpr,
-	"%":       rel.NewModExpr,
-	"-%":      rel.NewSubModExpr,
-	"//":      rel.NewIdivExpr,
-	"^":       rel.NewPowExpr,
-	"\\":      rel.NewOffsetExpr,
-	"+>":      rel.NewAddArrowExpr,
+	"->":         rel.NewArrowExpr,
+	"=>":         rel.NewDArrowExpr,
+	">>":         rel.NewSeqArrowExpr(false),
+	">>>":        rel.NewSeqArrowExpr(true),
+	":>":         rel.NewTupleMapExpr,
+	"orderby":    rel.NewOrderByExpr,
+	"order":      rel.NewOrderExpr,
+	"rank":       rel.NewRankExpr,
+	"where":      rel.NewWhereExpr,
+	"countWhere": rel.NewCountWhereExpr,
+	"any":        rel.NewAnyExpr,
+	"all":        rel.NewAllExpr,
+	"sum":        rel.NewSumExpr,
+	"max":        rel.NewMaxExpr,
+	"mean":       rel.NewMeanExpr,
+	"median":     rel.NewMedianExpr,
+	"min":        rel.NewMinExpr,
+	"split":      NewSplitExpr,
+	"join":       NewJoinExpr,
+	"cross":      rel.NewCartesianExpr,
+	"distinctby": rel.NewUniqueByExpr,
+	"histogram":  rel.NewCountByExpr,
+	"partition":  rel.NewPartitionExpr,
+	"contains":   rel.NewContainsExpr,
+	"startsWith": rel.NewStartsWithExpr,
+	"endsWith":   rel.NewEndsWithExpr,
+	"takeWhile":  rel.NewTakeWhileExpr,
+	"dropWhile":  rel.NewDropWhileExpr,
+	"with":       rel.NewWithExpr,
+	"without":    rel.NewWithoutExpr,
+	"&&":         rel.NewAndExpr,
+	"||":         rel.NewOrExpr,
+	"+":          rel.NewAddExpr,
+	"-":          rel.NewSubExpr,
+	"++":         rel.NewConcatExpr,
+	"&~":         rel.NewDiffExpr,
+	"~~":         rel.NewSymmDiffExpr,
+	"&":          rel.NewIntersectExpr,
+	"|":          rel.NewUnionExpr,
+	"<&>":        rel.NewJoinExpr,
+	"<->":        rel.NewComposeExpr,
+	"-&-":        rel.NewJoinCommonExpr,
+	"---":        rel.NewJoinExistsExpr,
+	"-&>":        rel.NewRightMatchExpr,
+	"<&-":        rel.NewLeftMatchExpr,
+	"-->":        rel.NewRightResidueExpr,
+	"<--":        rel.NewLeftResidueExpr,
+	"*":          rel.NewMulExpr,
+	"/":          rel.NewDivExpr,
+	"%":          rel.NewModExpr,
+	"-%":         rel.NewSubModExpr,
+	"//":         rel.NewIdivExpr,
+	"^":          rel.NewPowExpr,
+	"\\":         rel.NewOffsetExpr,
+	"+>":         rel.NewAddArrowExpr,
 }
 
 var compareOps = map[string]rel.CompareFunc{
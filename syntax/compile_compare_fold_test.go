@@ -0,0 +1,45 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/arr-ai/arrai/rel"
+)
+
+func TestFoldCompareLiterals(t *testing.T) {
+	t.Parallel()
+
+	for _, c := range []struct {
+		code string
+		want rel.Value
+	}{
+		{`2 < 3`, rel.True},
+		{`3 < 2`, rel.False},
+		{`"a" = "a"`, rel.True},
+		{`"a" = "b"`, rel.False},
+		{`1 <: {1, 2}`, rel.True},
+		{`3 <: {1, 2}`, rel.False},
+	} {
+		expr, err := mustCompileWith(t, ParseContext{}, c.code)
+		require.NoError(t, err)
+		require.IsTypef(t, rel.FoldedExpr{}, expr, "expected %q to fold at compile time", c.code)
+
+		val, err := expr.(rel.Expr).Eval(rel.EmptyScope)
+		require.NoError(t, err)
+		require.True(t, val.Equal(c.want), "expected %q to eval to %v, got %v", c.code, c.want, val)
+	}
+
+	AssertCodesEvalToSameValue(t, `true`, `2 < 3`)
+	AssertCodesEvalToSameValue(t, `false`, `3 < 2`)
+	AssertCodesEvalToSameValue(t, `true`, `"a" = "a"`)
+	AssertCodesEvalToSameValue(t, `true`, `1 <: {1, 2}`)
+	AssertCodesEvalToSameValue(t, `false`, `3 <: {1, 2}`)
+
+	// Dynamic operands are left as a regular CompareExpr, not folded.
+	expr, err := mustCompileWith(t, ParseContext{}, `let x = 2; x < 3`)
+	require.NoError(t, err)
+	require.NotPanics(t, func() { _ = expr.(rel.Expr).String() })
+	AssertCodesEvalToSameValue(t, `true`, `let x = 2; x < 3`)
+}
@@ -0,0 +1,36 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustCompileWith(t *testing.T, pc ParseContext, code string) (expr interface{}, err error) {
+	t.Helper()
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok {
+				err = e
+			} else {
+				t.Fatalf("unexpected panic: %v", r)
+			}
+		}
+	}()
+	ast, parseErr := pc.Parse(parser.NewScanner(code))
+	require.NoError(t, parseErr)
+	return pc.CompileExpr(ast), nil
+}
+
+func TestDisallowEval(t *testing.T) {
+	t.Parallel()
+
+	_, err := mustCompileWith(t, ParseContext{DisallowEval: true}, `*"1"`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "dynamic eval not permitted")
+
+	_, err = mustCompileWith(t, ParseContext{}, `*"1"`)
+	assert.NoError(t, err)
+}
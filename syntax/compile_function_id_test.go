@@ -0,0 +1,37 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/arr-ai/arrai/rel"
+)
+
+func compileFunctionForID(t *testing.T, code string) *rel.Function {
+	t.Helper()
+	expr, err := mustCompileWith(t, ParseContext{AttachFunctionIDs: true}, code)
+	require.NoError(t, err)
+	fn, ok := expr.(*rel.Function)
+	require.Truef(t, ok, "expected %q to compile to a *rel.Function, got %T", code, expr)
+	return fn
+}
+
+func TestAttachFunctionIDs(t *testing.T) {
+	t.Parallel()
+
+	fn1 := compileFunctionForID(t, `\x x + 1`)
+	fn2 := compileFunctionForID(t, `\x x + 1`)
+	require.NotEmpty(t, fn1.ID())
+	require.Equal(t, fn1.ID(), fn2.ID(), "identical source should produce equal function IDs")
+
+	fn3 := compileFunctionForID(t, `\x x + 2`)
+	require.NotEqual(t, fn1.ID(), fn3.ID(), "different source should produce different function IDs")
+
+	// Without the flag, compiled functions get no ID.
+	expr, err := mustCompileWith(t, ParseContext{}, `\x x + 1`)
+	require.NoError(t, err)
+	fn, ok := expr.(*rel.Function)
+	require.True(t, ok)
+	require.Empty(t, fn.ID())
+}
@@ -0,0 +1,54 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestImportAsAliasesPackage checks that `import //pkg as alias; body` binds
+// alias to the package, for both a std package and a local file import.
+func TestImportAsAliasesPackage(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `3.141592653589793`, `import //math as m; m.pi`)
+	AssertCodesEvalToSameValue(t, `{1, 4, 9, 16}`,
+		`import //{./examples/simple/simple} as simple; simple`)
+}
+
+// TestImportAsRejectsCollidingAlias checks that an alias whose name is bound
+// again later in the same chain of directly-sequential let-like statements
+// (by a let or another import) is always a compile error, regardless of
+// StrictRebinding.
+func TestImportAsRejectsCollidingAlias(t *testing.T) {
+	t.Parallel()
+
+	_, err := mustCompileWith(t, ParseContext{}, `import //math as m; let m = 1; m`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"m" is already bound in this scope`)
+
+	_, err = mustCompileWith(t, ParseContext{}, `import //math as m; import //strings as m; m`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"m" is already bound in this scope`)
+}
+
+// TestImportAsRejectsCollidingAliasLetFirst is like
+// TestImportAsRejectsCollidingAlias, but with the colliding let (or
+// uniondef) preceding the importAs instead of following it, checking that
+// the same collision is caught regardless of which form comes first.
+func TestImportAsRejectsCollidingAliasLetFirst(t *testing.T) {
+	t.Parallel()
+
+	_, err := mustCompileWith(t, ParseContext{}, `let m = 1; import //math as m; m`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"m" is already bound in this scope`)
+
+	_, err = mustCompileWith(t, ParseContext{}, `let m = 1, n = 2; import //math as m; m`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"m" is already bound in this scope`)
+
+	_, err = mustCompileWith(t, ParseContext{}, `|Shape| m(r); import //math as m; m`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"m" is already bound in this scope`)
+}
@@ -0,0 +1,129 @@
+package syntax
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/arr-ai/arrai/rel"
+)
+
+// TestOrderByWhereFusion checks that `s orderby key where pred`, with pred
+// independent of element position, compiles to a rel.OrderByWhereExpr that
+// filters before sorting (rather than the sort-then-filter the source
+// wrote), while still evaluating to the same elements in the same order as
+// `(s where pred) orderby key` -- including raising the same error when s
+// holds keys of inconsistent kinds, even if every surviving (post-filter)
+// key is consistent. See TestOrderByWhereFusionChecksConsistentKindsAcrossS.
+func TestOrderByWhereFusion(t *testing.T) {
+	t.Parallel()
+
+	s := `{(k: 3), (k: 1), (k: 2), (k: 9)}`
+
+	fused, err := mustCompileWith(t, ParseContext{}, s+` orderby .k where .k > 1`)
+	require.NoError(t, err)
+	require.IsType(t, &rel.OrderByWhereExpr{}, fused)
+
+	AssertCodesEvalToSameValue(t, `(`+s+` where .k > 1) orderby .k`, s+` orderby .k where .k > 1`)
+	AssertCodesEvalToSameValue(t, `[(k: 2), (k: 3), (k: 9)]`, s+` orderby .k where .k > 1`)
+}
+
+// TestOrderByWhereFusionChecksConsistentKindsAcrossS checks that fusing
+// `orderby key where pred` still validates key-kind consistency across all
+// of s, not just the elements pred keeps, so filtering out the
+// inconsistent element can't silently suppress the error an equivalent
+// unfused `(s orderby key) where pred` would raise.
+func TestOrderByWhereFusionChecksConsistentKindsAcrossS(t *testing.T) {
+	t.Parallel()
+
+	s := `{(k: 3), (k: 1), (k: "x")}`
+
+	AssertCodeErrors(t, "key values must be of a consistent type", `(`+s+` orderby .k) where .k != "x"`)
+	AssertCodeErrors(t, "key values must be of a consistent type", s+` orderby .k where .k != "x"`)
+}
+
+// TestOrderByWhereFusionSkipsPositionDependentPredicates checks that a
+// `where` predicate referencing an array element's position (`.@`) or its
+// wrapped value (`.@item`) is left sort-then-filter, since those attrs only
+// exist on the orderby's own Array output.
+func TestOrderByWhereFusionSkipsPositionDependentPredicates(t *testing.T) {
+	t.Parallel()
+
+	s := `{(k: 3), (k: 1), (k: 2), (k: 9)}`
+
+	for _, pred := range []string{`.@ > 1`, `.@item.k > 1`} {
+		expr, err := mustCompileWith(t, ParseContext{}, s+` orderby .k where `+pred)
+		require.NoError(t, err)
+		str := expr.(rel.Expr).String()
+		require.True(t, strings.HasPrefix(str, "(("),
+			"expected sort-then-filter (orderby compiled first) for %q, got: %s", pred, str)
+	}
+
+	// Sort-then-filter: sorted order is k=1,2,3,9 at positions 0-3; .@ > 1
+	// keeps positions 2 and 3, retaining their original offset, so compare
+	// values directly rather than via an offset-sensitive Array literal.
+	expr, err := mustCompileWith(t, ParseContext{}, s+` orderby .k where .@ > 1 >> .k`)
+	require.NoError(t, err)
+	value, err := expr.(rel.Expr).Eval(rel.EmptyScope)
+	require.NoError(t, err)
+	arr, is := rel.AsArray(value)
+	require.True(t, is, "expected an Array, got %v", value)
+	require.Equal(t, []rel.Value{rel.NewNumber(3), rel.NewNumber(9)}, arr.Values())
+}
+
+// buildOrderByWhereFusionBenchmarkSet returns a literal set of n tuples,
+// only one of which satisfies `.k = 1`, for BenchmarkOrderByWhereFusion.
+func buildOrderByWhereFusionBenchmarkSet(n int) string {
+	var sb strings.Builder
+	sb.WriteString("{")
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "(k: %d)", n-i)
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// BenchmarkOrderByWhereFusion compares the fused `orderby ... where ...`
+// (filter down to one element, then sort that) against the equivalent
+// sort-then-filter written out explicitly (sort every element, then filter),
+// over the same large set. Fusion does one comparison per filtered
+// survivor instead of the O(n log n) comparisons needed to sort the whole
+// set first.
+func BenchmarkOrderByWhereFusion(b *testing.B) {
+	s := buildOrderByWhereFusionBenchmarkSet(10000)
+
+	b.Run("Fused", func(b *testing.B) {
+		pc := ParseContext{}
+		expr, err := mustCompileWith(&testing.T{}, pc, s+` orderby .k where .k = 1`)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := expr.(rel.Expr).Eval(rel.EmptyScope); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("SortThenFilter", func(b *testing.B) {
+		pc := ParseContext{}
+		// Parenthesizing the orderby keeps it in its own sub-expr, so the
+		// following where can't fuse into it.
+		expr, err := mustCompileWith(&testing.T{}, pc, `(`+s+` orderby .k) where .@item.k = 1`)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := expr.(rel.Expr).Eval(rel.EmptyScope); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
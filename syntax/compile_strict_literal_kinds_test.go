@@ -0,0 +1,49 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// compileForLiteralKindWarnings compiles code under StrictLiteralKinds and
+// returns the warning messages raised, via LiteralKindWarning, for mixed
+// array/set literal elements.
+func compileForLiteralKindWarnings(t *testing.T, code string) []string {
+	t.Helper()
+	var warnings []string
+	pc := ParseContext{
+		StrictLiteralKinds: true,
+		LiteralKindWarning: func(msg string, pos parser.Scanner) {
+			warnings = append(warnings, msg)
+		},
+	}
+	b, err := pc.Parse(parser.NewScanner(code))
+	require.NoError(t, err)
+	pc.CompileExpr(b)
+	return warnings
+}
+
+func TestStrictLiteralKindsMixedArrayWarns(t *testing.T) {
+	t.Parallel()
+
+	warnings := compileForLiteralKindWarnings(t, `[1, (a: 1), 3]`)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "array literal mixes number and tuple elements")
+
+	warnings = compileForLiteralKindWarnings(t, `{1, 2, (a: 1)}`)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "set literal mixes number and tuple elements")
+}
+
+func TestStrictLiteralKindsHomogeneousArrayIsSilent(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, compileForLiteralKindWarnings(t, `[1, 2, 3]`))
+	assert.Empty(t, compileForLiteralKindWarnings(t, `{"a", "b", "c"}`))
+
+	// Dynamic (non-literal) elements are exempt from the check.
+	assert.Empty(t, compileForLiteralKindWarnings(t, `let x = (a: 1); [1, x, 3]`))
+}
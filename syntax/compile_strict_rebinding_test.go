@@ -0,0 +1,40 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrictRebindingSameScope(t *testing.T) {
+	t.Parallel()
+
+	pc := ParseContext{StrictRebinding: true}
+
+	_, err := mustCompileWith(t, pc, `let x = 1; let x = 2; x`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"x" is already bound in this scope`)
+
+	_, err = mustCompileWith(t, pc, `let x = 1, x = 2; x`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"x" is already bound in this scope`)
+
+	_, err = mustCompileWith(t, ParseContext{}, `let x = 1; let x = 2; x`)
+	assert.NoError(t, err)
+}
+
+func TestStrictRebindingNestedScopeShadowingAllowed(t *testing.T) {
+	t.Parallel()
+
+	pc := ParseContext{StrictRebinding: true}
+
+	_, err := mustCompileWith(t, pc, `let x = 1; \x x + 1`)
+	assert.NoError(t, err)
+
+	_, err = mustCompileWith(t, pc, `let x = 1; (\x let x = 2; x)(3)`)
+	assert.NoError(t, err)
+
+	_, err = mustCompileWith(t, pc, `let x = 1; let y = x + 1; y`)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,105 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// compileForUnionExhaustivenessWarnings compiles code with
+// StrictUnionExhaustiveness left false and returns the warning messages
+// raised, via UnionExhaustivenessWarning, for a cond over a known union that
+// doesn't handle every variant.
+func compileForUnionExhaustivenessWarnings(t *testing.T, code string) []string {
+	t.Helper()
+	var warnings []string
+	pc := ParseContext{
+		UnionExhaustivenessWarning: func(msg string, pos parser.Scanner) {
+			warnings = append(warnings, msg)
+		},
+	}
+	b, err := pc.Parse(parser.NewScanner(code))
+	require.NoError(t, err)
+	pc.CompileExpr(b)
+	return warnings
+}
+
+const shapeUnion = `|Shape| Circle(r) | Square(s);`
+
+func TestUnionExhaustivenessExhaustiveMatchIsSilent(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, compileForUnionExhaustivenessWarnings(t, shapeUnion+`
+		let shape = Circle(3);
+		cond shape {
+			(tag: "Circle", r: r): r,
+			(tag: "Square", s: s): s,
+		}`))
+}
+
+func TestUnionExhaustivenessDefaultCaseIsSilent(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, compileForUnionExhaustivenessWarnings(t, shapeUnion+`
+		let shape = Circle(3);
+		cond shape {
+			(tag: "Circle", r: r): r,
+			_: 0,
+		}`))
+}
+
+func TestUnionExhaustivenessMissingVariantWarns(t *testing.T) {
+	t.Parallel()
+
+	warnings := compileForUnionExhaustivenessWarnings(t, shapeUnion+`
+		let shape = Circle(3);
+		cond shape {
+			(tag: "Circle", r: r): r,
+		}`)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "cond doesn't handle union variant(s) Square")
+}
+
+func TestUnionExhaustivenessStrictModePanics(t *testing.T) {
+	t.Parallel()
+
+	pc := ParseContext{StrictUnionExhaustiveness: true}
+	b, err := pc.Parse(parser.NewScanner(shapeUnion + `
+		let shape = Circle(3);
+		cond shape {
+			(tag: "Circle", r: r): r,
+		}`))
+	require.NoError(t, err)
+
+	defer func() {
+		r := recover()
+		require.NotNil(t, r)
+		err, ok := r.(error)
+		require.True(t, ok)
+		assert.Contains(t, err.Error(), "cond doesn't handle union variant(s) Square")
+	}()
+	pc.CompileExpr(b)
+}
+
+func TestUnionExhaustivenessCondOutsideUnionBodyIsUnchecked(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, compileForUnionExhaustivenessWarnings(t, `
+		cond (1) {
+			(tag: "Circle", r: r): r,
+		}`))
+}
+
+// TestUnionExhaustivenessUnrelatedCondInTrailingBodyIsUnchecked checks
+// that a cond appearing later in a uniondef's body, but whose patterns
+// don't recognizably switch on the union's tag (e.g. plain numeric
+// literals), isn't flagged as failing to handle that union's variants.
+func TestUnionExhaustivenessUnrelatedCondInTrailingBodyIsUnchecked(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, compileForUnionExhaustivenessWarnings(t, shapeUnion+`
+		let unrelated = cond 5 { 0: "a", 1: "b", 5: "c" };
+		unrelated`))
+}
@@ -0,0 +1,59 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/stretchr/testify/require"
+
+	"github.com/arr-ai/arrai/rel"
+)
+
+func TestStringLiteralHookRecordsLiterals(t *testing.T) {
+	t.Parallel()
+
+	var recorded []string
+	pc := ParseContext{
+		StringLiteralHook: func(s string, pos parser.Scanner) rel.Expr {
+			recorded = append(recorded, s)
+			return nil
+		},
+	}
+
+	expr, err := mustCompileWith(t, pc, `["hello", "world"]`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"hello", "world"}, recorded)
+
+	val, err := expr.(rel.Expr).Eval(rel.EmptyScope)
+	require.NoError(t, err)
+	require.True(t, val.Equal(rel.NewArray(
+		rel.NewString([]rune("hello")),
+		rel.NewString([]rune("world")),
+	)))
+}
+
+func TestStringLiteralHookRewritesLiterals(t *testing.T) {
+	t.Parallel()
+
+	catalog := map[string]string{"greeting": "hello"}
+	pc := ParseContext{
+		StringLiteralHook: func(s string, pos parser.Scanner) rel.Expr {
+			if translated, ok := catalog[s]; ok {
+				return rel.NewLiteralExpr(pos, rel.NewString([]rune(translated)))
+			}
+			return nil
+		},
+	}
+
+	expr, err := mustCompileWith(t, pc, `"greeting"`)
+	require.NoError(t, err)
+	val, err := expr.(rel.Expr).Eval(rel.EmptyScope)
+	require.NoError(t, err)
+	require.True(t, val.Equal(rel.NewString([]rune("hello"))))
+
+	expr, err = mustCompileWith(t, pc, `"other"`)
+	require.NoError(t, err)
+	val, err = expr.(rel.Expr).Eval(rel.EmptyScope)
+	require.NoError(t, err)
+	require.True(t, val.Equal(rel.NewString([]rune("other"))))
+}
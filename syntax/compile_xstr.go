@@ -12,7 +12,7 @@ import (
 var leadingWSRE = regexp.MustCompile(`\A[\t ]*`)
 var trailingWSRE = regexp.MustCompile(`[\t ]*\z`)
 var lastWSRE = regexp.MustCompile(`\n[\t ]+\z`)
-var expansionRE = regexp.MustCompile(`(?::([-+#*\.\_0-9a-z]*))(:(?:\\.|[^\\:}])*)?(?::((?:\\.|[^\\:}])*))?`)
+var expansionRE = regexp.MustCompile(`(?::([-+#*\.\_0-9a-z,]*))(:(?:\\.|[^\\:}])*)?(?::((?:\\.|[^\\:}])*))?`)
 
 func (pc ParseContext) compileExpandableString(b ast.Branch, c ast.Children) rel.Expr {
 	scanner := c.(ast.One).Node.One("quote").Scanner()
@@ -107,6 +107,17 @@ func (pc ParseContext) compileExpandableString(b ast.Branch, c ast.Children) rel
 				}
 				next = ""
 			}
+			if strings.Contains(format, ",") {
+				format = strings.Replace(format, ",", "", 1)
+				if format == "" {
+					format = "d"
+				}
+				groupSep := pc.NumberGroupSeparator
+				if groupSep == "" {
+					groupSep = ","
+				}
+				format = groupSepMarker + groupSep + groupSepMarker + format
+			}
 			exprs[i] = rel.NewCallExprCurry(part.Scanner(), stdStrExpand,
 				rel.NewString([]rune(format)),
 				pc.CompileExpr(part.One("expr").(ast.Branch)),
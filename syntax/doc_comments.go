@@ -0,0 +1,62 @@
+package syntax
+
+import "strings"
+
+// Doc is a doc comment associated with a top-level `let` binding.
+type Doc struct {
+	Name    string
+	Comment string
+	Pos     int
+}
+
+// DocComments scans source for `let NAME = ...;` bindings that start a line
+// (after any indentation; nesting isn't tracked, so a let inside another
+// expression is included too) and returns, for each one preceded by a block
+// of `#` comment lines, the binding's name, the comment text (with the
+// leading `#` and surrounding whitespace stripped from each line), and the
+// byte offset of the `let` keyword. Blank lines don't end a comment block;
+// any other line does. Bindings with no preceding comment block are
+// omitted.
+//
+// This is a line-oriented scan rather than a parser pass: the scanner
+// currently discards comment trivia, so DocComments looks at the raw
+// source text directly instead of the compiled AST.
+func DocComments(source string) ([]Doc, error) {
+	var docs []Doc
+	var comment []string
+	pos := 0
+	for _, line := range strings.Split(source, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "#"):
+			comment = append(comment, strings.TrimSpace(strings.TrimPrefix(trimmed, "#")))
+		case strings.HasPrefix(trimmed, "let "):
+			if name := letBindingName(trimmed); name != "" && len(comment) > 0 {
+				docs = append(docs, Doc{
+					Name:    name,
+					Comment: strings.Join(comment, "\n"),
+					Pos:     pos + strings.Index(line, "let"),
+				})
+			}
+			comment = nil
+		case trimmed == "":
+			// Blank lines don't break a doc comment block.
+		default:
+			comment = nil
+		}
+		pos += len(line) + 1
+	}
+	return docs, nil
+}
+
+// letBindingName extracts NAME from a line of the form `let NAME = ...;`,
+// returning "" if the line doesn't match that shape.
+func letBindingName(trimmed string) string {
+	rest := strings.TrimSpace(strings.TrimPrefix(trimmed, "let "))
+	rest = strings.TrimPrefix(rest, "rec ")
+	i := strings.IndexAny(rest, " \t=")
+	if i <= 0 {
+		return ""
+	}
+	return rest[:i]
+}
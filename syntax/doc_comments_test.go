@@ -0,0 +1,33 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocComments(t *testing.T) {
+	t.Parallel()
+
+	source := `
+# Pi is the ratio of a circle's circumference to its diameter.
+let pi = 3.14159;
+
+let undocumented = 1;
+
+# Greet returns a friendly greeting.
+# name must not be empty.
+let greet = \name $"Hello, ${name}!";
+
+greet(pi)
+`
+	docs, err := DocComments(source)
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+
+	require.Equal(t, "pi", docs[0].Name)
+	require.Equal(t, "Pi is the ratio of a circle's circumference to its diameter.", docs[0].Comment)
+
+	require.Equal(t, "greet", docs[1].Name)
+	require.Equal(t, "Greet returns a friendly greeting.\nname must not be empty.", docs[1].Comment)
+}
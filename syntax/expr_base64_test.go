@@ -0,0 +1,37 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBase64RoundTripStandard(t *testing.T) {
+	t.Parallel()
+	AssertCodesEvalToSameValue(t,
+		`let a = <<1, 2, 3>>; a`,
+		`let a = <<1, 2, 3>>; base64decode(base64encode(a, false), false)`)
+}
+
+func TestBase64RoundTripURLSafe(t *testing.T) {
+	t.Parallel()
+	AssertCodesEvalToSameValue(t,
+		`let a = <<255, 239>>; a`,
+		`let a = <<255, 239>>; base64decode(base64encode(a, true), true)`)
+}
+
+func TestBase64URLSafeUsesDifferentAlphabet(t *testing.T) {
+	t.Parallel()
+	AssertCodesEvalToSameValue(t, `"/+8="`, `base64encode(<<255, 239>>, false)`)
+	AssertCodesEvalToSameValue(t, `"_-8="`, `base64encode(<<255, 239>>, true)`)
+}
+
+func TestBase64DecodeMalformedInputIsCatchable(t *testing.T) {
+	t.Parallel()
+	_, err := EvaluateExpr("", `base64decode("not valid base64!!", false)`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "base64decode")
+
+	AssertCodesEvalToSameValue(t, `"caught"`,
+		`try base64decode("not valid base64!!", false) catch base64Decode "caught"`)
+}
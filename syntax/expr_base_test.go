@@ -0,0 +1,54 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestToBaseHex checks rendering a number in base 16.
+func TestToBaseHex(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"ff"`, `toBase(255, 16)`)
+}
+
+// TestToBaseBinary checks rendering a number in base 2.
+func TestToBaseBinary(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"101"`, `toBase(5, 2)`)
+}
+
+// TestFromBaseHexRoundTrip checks that fromBase inverts toBase for hex.
+func TestFromBaseHexRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `255`, `fromBase(toBase(255, 16), 16)`)
+}
+
+// TestFromBaseBase36RoundTrip checks that fromBase inverts toBase for
+// base 36.
+func TestFromBaseBase36RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `12345`, `fromBase(toBase(12345, 36), 36)`)
+}
+
+// TestToBaseOutOfRangeLiteralIsCompileError checks that a base statically
+// known to be out of [2, 36] fails at compile time.
+func TestToBaseOutOfRangeLiteralIsCompileError(t *testing.T) {
+	t.Parallel()
+
+	_, err := Compile(NoPath, `toBase(1, 1)`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "base must be a whole number in [2, 36]")
+}
+
+// TestFromBaseInvalidDigitIsCatchable checks that an s with digits invalid
+// for base is a catchable error, not a panic.
+func TestFromBaseInvalidDigitIsCatchable(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"bad"`, `try fromBase("zz", 10) catch fromBase "bad"`)
+}
@@ -1,7 +1,13 @@
 package syntax
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/arr-ai/arrai/rel"
 )
 
 func TestWhereExpr(t *testing.T) {
@@ -11,6 +17,128 @@ func TestWhereExpr(t *testing.T) {
 	AssertCodesEvalToSameValue(t, `{(a:3, b:41)}`, s+` where .a=3`)
 }
 
+// TestWhereInExpr checks that `s where \t t.attr <: allowed` compiles to the
+// optimized rel.WhereInExpr when allowed is a literal set, and keeps
+// evaluating to the same result as the general-purpose `where`.
+func TestWhereInExpr(t *testing.T) {
+	t.Parallel()
+	s := `{|a,b| (3,41), (2,42), (1,43)}`
+
+	expr, err := mustCompileWith(t, ParseContext{}, s+` where \t t.a <: {1, 3}`)
+	require.NoError(t, err)
+	require.IsTypef(t, rel.WhereInExpr{}, expr, "expected where-in shape to compile to an indexed lookup")
+
+	AssertCodesEvalToSameValue(t, `{(a:3, b:41), (a:1, b:43)}`, s+` where \t t.a <: {1, 3}`)
+	AssertCodesEvalToSameValue(t, `{}`, s+` where \t t.a <: {4, 5}`)
+
+	// Dynamic operands (allowed isn't a literal) are left as a regular where.
+	expr, err = mustCompileWith(t, ParseContext{}, `let allowed = {1, 3}; `+s+` where \t t.a <: allowed`)
+	require.NoError(t, err)
+	require.NotPanics(t, func() { _ = expr.(rel.Expr).String() })
+	AssertCodesEvalToSameValue(t,
+		`{(a:3, b:41), (a:1, b:43)}`,
+		`let allowed = {1, 3}; `+s+` where \t t.a <: allowed`,
+	)
+}
+
+// TestOrderByNulls checks `orderby ... nulls first|last`, which orders a
+// relation by a key that some tuples lack, placing those tuples as a block
+// before or after every tuple with a present key.
+func TestOrderByNulls(t *testing.T) {
+	t.Parallel()
+	s := `{(k: 3), (k: 1), (), (k: 2)}`
+
+	expr, err := mustCompileWith(t, ParseContext{}, s+` orderby \t t.k nulls last`)
+	require.NoError(t, err)
+	require.IsTypef(t, &rel.OrderByNullsExpr{}, expr, "expected nulls-aware orderby to compile to OrderByNullsExpr")
+
+	AssertCodesEvalToSameValue(t, `[(k: 1), (k: 2), (k: 3), ()]`, s+` orderby \t t.k nulls last`)
+	AssertCodesEvalToSameValue(t, `[(), (k: 1), (k: 2), (k: 3)]`, s+` orderby \t t.k nulls first`)
+
+	// With no missing keys, nulls first/last has no effect.
+	AssertCodesEvalToSameValue(t,
+		`[(k: 1), (k: 2), (k: 3)]`,
+		`{(k: 3), (k: 1), (k: 2)} orderby \t t.k nulls first`,
+	)
+
+	// `nulls first`/`nulls last` is only meaningful for orderby.
+	AssertCodePanics(t, s+` where \t t.k nulls first`)
+}
+
+// TestConcatFold checks that chains of `++` over literal strings/arrays are
+// folded into a single literal at compile time, and that a chain mixing
+// literal and dynamic operands folds only its maximal literal runs, leaving
+// the rest to evaluate at runtime in the same order as written.
+func TestConcatFold(t *testing.T) {
+	t.Parallel()
+
+	expr, err := mustCompileWith(t, ParseContext{}, `"a" ++ "b" ++ "c"`)
+	require.NoError(t, err)
+	require.IsTypef(t, rel.FoldedExpr{}, expr, "expected all-literal ++ chain to fold to a single literal")
+	AssertCodesEvalToSameValue(t, `"abc"`, `"a" ++ "b" ++ "c"`)
+
+	// Only the "a" ++ "b" run is literal; x and y are dynamic, so the
+	// compiled tree keeps them in their original positions and order,
+	// folding just the literal run between them.
+	expr, err = mustCompileWith(t, ParseContext{}, `x ++ "a" ++ "b" ++ y`)
+	require.NoError(t, err)
+	require.Equal(t, `((x ++ (a ++ b)) ++ y)`, expr.(rel.Expr).String())
+
+	AssertCodesEvalToSameValue(t, `"1ab2"`, `let x = "1"; let y = "2"; x ++ "a" ++ "b" ++ y`)
+	AssertCodesEvalToSameValue(t, `[1, 2, 3, 4, 5]`, `let x = [1]; x ++ [2, 3] ++ [4] ++ [5]`)
+}
+
+func buildWhereInBenchmarkSet(n int) string {
+	var sb strings.Builder
+	sb.WriteString("{|k,v| ")
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "(%d, %d)", i, i)
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// BenchmarkWhereIn compares the optimized `t.k <: allowed` where-in lookup
+// against a semantically equivalent general-purpose where predicate over the
+// same large relation, filtered down to a small allowed set.
+func BenchmarkWhereIn(b *testing.B) {
+	s := buildWhereInBenchmarkSet(10000)
+	allowed := "{1, 42, 100, 9999}"
+
+	b.Run("Indexed", func(b *testing.B) {
+		pc := ParseContext{}
+		expr, err := mustCompileWith(&testing.T{}, pc, s+` where \t t.k <: `+allowed)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := expr.(rel.Expr).Eval(rel.EmptyScope); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("Scan", func(b *testing.B) {
+		pc := ParseContext{}
+		// `+ 0` prevents the `.k <: allowed` shape from being recognized,
+		// forcing the general-purpose closure-call where path.
+		expr, err := mustCompileWith(&testing.T{}, pc, s+` where \t (t.k + 0) <: `+allowed)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := expr.(rel.Expr).Eval(rel.EmptyScope); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
 func TestRelationCall(t *testing.T) {
 	t.Parallel()
 	s := `{"key": "val"}("key")`
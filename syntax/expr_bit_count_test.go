@@ -0,0 +1,47 @@
+package syntax
+
+import "testing"
+
+// TestPopcountZeroIsZero checks that popcount(0) is 0.
+func TestPopcountZeroIsZero(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `0`, `popcount(0)`)
+}
+
+// TestPopcountCountsSetBits checks that popcount counts the set bits of n.
+func TestPopcountCountsSetBits(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `3`, `popcount(7)`)
+}
+
+// TestLeadingZerosOfOne checks leadingZeros of 1 in a 64-bit
+// representation.
+func TestLeadingZerosOfOne(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `63`, `leadingZeros(1)`)
+}
+
+// TestTrailingZerosOfEight checks trailingZeros(8) == 3.
+func TestTrailingZerosOfEight(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `3`, `trailingZeros(8)`)
+}
+
+// TestTrailingZerosOfZeroIsSixtyFour checks that trailingZeros(0) is the
+// full 64-bit width, since 0 has no set bits.
+func TestTrailingZerosOfZeroIsSixtyFour(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `64`, `trailingZeros(0)`)
+}
+
+// TestPopcountNonIntegerIsError checks that a non-integer n is an error.
+func TestPopcountNonIntegerIsError(t *testing.T) {
+	t.Parallel()
+
+	AssertCodeErrors(t, "popcount: n must be a non-negative whole number", `popcount(1.5)`)
+}
@@ -0,0 +1,26 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCartesianOperator(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t,
+		`{
+			(x: 1, y: 1), (x: 1, y: 2), (x: 1, y: 3),
+			(x: 2, y: 1), (x: 2, y: 2), (x: 2, y: 3)
+		}`,
+		`{(x: 1), (x: 2)} cross {(y: 1), (y: 2), (y: 3)}`)
+}
+
+func TestCartesianOperatorNameCollisionErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := EvaluateExpr("", `{(a: 1, b: 2)} cross {(a: 3)}`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cross attrs overlap")
+}
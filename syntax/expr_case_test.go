@@ -0,0 +1,36 @@
+package syntax
+
+import "testing"
+
+// TestUpperConvertsAccentedLetters checks that upper uppercases accented
+// letters correctly, not just ASCII.
+func TestUpperConvertsAccentedLetters(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"CAFÉ"`, `upper("café")`)
+}
+
+// TestUpperLeavesSharpSUnchanged checks the documented simple-case-mapping
+// caveat: "ß" has no single-rune uppercase form, so upper leaves it as is
+// rather than expanding it to "SS".
+func TestUpperLeavesSharpSUnchanged(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"STRAßE"`, `upper("straße")`)
+}
+
+// TestLowerConvertsAccentedLetters checks that lower lowercases accented
+// letters correctly, not just ASCII.
+func TestLowerConvertsAccentedLetters(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"café"`, `lower("CAFÉ")`)
+}
+
+// TestTitleCapitalizesEachWord checks that title capitalizes the first
+// letter of each word, including accented letters.
+func TestTitleCapitalizesEachWord(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"Café Au Lait"`, `title("café au lait")`)
+}
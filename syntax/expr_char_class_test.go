@@ -0,0 +1,35 @@
+package syntax
+
+import (
+	"testing"
+)
+
+func TestIsDigitAcceptsCharOrString(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `true`, `isDigit(%5)`)
+	AssertCodesEvalToSameValue(t, `true`, `isDigit('5')`)
+	AssertCodesEvalToSameValue(t, `false`, `isDigit('a')`)
+	AssertCodesEvalToSameValue(t, `true`, `isDigit('٥')`) // Arabic-Indic digit five
+}
+
+func TestIsLetterAcceptsCharOrString(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `true`, `isLetter('a')`)
+	AssertCodesEvalToSameValue(t, `true`, `isLetter('é')`)
+	AssertCodesEvalToSameValue(t, `false`, `isLetter('5')`)
+}
+
+func TestIsSpaceAcceptsCharOrString(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `true`, `isSpace(' ')`)
+	AssertCodesEvalToSameValue(t, `false`, `isSpace('a')`)
+}
+
+func TestCharClassMultiRuneStringIsError(t *testing.T) {
+	t.Parallel()
+
+	AssertCodeErrors(t, `char class: "ab" is not a single char`, `isDigit("ab")`)
+}
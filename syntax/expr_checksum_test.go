@@ -0,0 +1,49 @@
+package syntax
+
+import "testing"
+
+// TestSHA256OfEmptyString checks sha256 against the well-known empty-input
+// test vector.
+func TestSHA256OfEmptyString(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(
+		t,
+		`"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"`,
+		`sha256("")`,
+	)
+}
+
+// TestSHA256OfString checks sha256 against the standard "abc" test vector.
+func TestSHA256OfString(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(
+		t,
+		`"ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"`,
+		`sha256("abc")`,
+	)
+}
+
+// TestSHA256OfBytesMatchesEquivalentString checks that sha256 accepts Bytes
+// directly, agreeing with the UTF-8 encoding of the equivalent String.
+func TestSHA256OfBytesMatchesEquivalentString(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `sha256("abc")`, `sha256(<<97, 98, 99>>)`)
+}
+
+// TestMD5OfString checks md5 against the standard "abc" test vector.
+func TestMD5OfString(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"900150983cd24fb0d6963f7d28e17f72"`, `md5("abc")`)
+}
+
+// TestCRC32OfString checks crc32 against the standard IEEE "abc" test
+// vector.
+func TestCRC32OfString(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"352441c2"`, `crc32("abc")`)
+}
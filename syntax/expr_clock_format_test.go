@@ -0,0 +1,33 @@
+package syntax
+
+import (
+	"testing"
+)
+
+func TestFormatTimeFixedTime(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"2023-11-14T22:13:20Z"`,
+		`formatTime(1700000000, "2006-01-02T15:04:05Z")`)
+}
+
+func TestParseTimeParsesFormattedTimeBack(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `1700000000`,
+		`parseTime("2023-11-14T22:13:20Z", "2006-01-02T15:04:05Z")`)
+}
+
+func TestFormatTimeParseTimeRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"2023-11-14T22:13:20Z"`,
+		`formatTime(parseTime("2023-11-14T22:13:20Z", "2006-01-02T15:04:05Z"), "2006-01-02T15:04:05Z")`)
+}
+
+func TestParseTimeMalformedInputIsCatchable(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"caught"`,
+		`try parseTime("not a time", "2006-01-02T15:04:05Z") catch parseTime "caught"`)
+}
@@ -0,0 +1,30 @@
+package syntax
+
+import "testing"
+
+func TestCoerceBoolFalsy(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `false`, `bool(0)`)
+	AssertCodesEvalToSameValue(t, `false`, `bool({})`)
+	AssertCodesEvalToSameValue(t, `false`, `bool("")`)
+	AssertCodesEvalToSameValue(t, `false`, `bool([])`)
+	AssertCodesEvalToSameValue(t, `false`, `bool(<<>>)`)
+}
+
+func TestCoerceBoolTruthy(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `true`, `bool(1)`)
+	AssertCodesEvalToSameValue(t, `true`, `bool(-1)`)
+	AssertCodesEvalToSameValue(t, `true`, `bool({1})`)
+	AssertCodesEvalToSameValue(t, `true`, `bool("a")`)
+	AssertCodesEvalToSameValue(t, `true`, `bool([1])`)
+}
+
+func TestCoerceBoolMatchesImplicitCoercion(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `true`, `bool(1) = cond{1: true, _: false}`)
+	AssertCodesEvalToSameValue(t, `true`, `bool(0) = cond{0: true, _: false}`)
+}
@@ -185,4 +185,32 @@ func TestEvalCondPatternMatchingWithControlVar(t *testing.T) { //nolint:dupl
 
 	AssertCodesEvalToSameValue(t, `{}`, `let a = 2; cond a {[1,2,3]: 6}`)
 	AssertCodesEvalToSameValue(t, `2`, `let a = {"a":3}; cond a {(a:x): x + 5,_:2}`)
+
+	// Tuple patterns can carry a nested fallback, defaulting a clause's
+	// attribute when it's missing from the control var.
+	AssertCodesEvalToSameValue(t, `3`, `cond (a: 1, b: 2) { (:a, :b: 0): a + b, _: -1 }`)
+	AssertCodesEvalToSameValue(t, `1`, `cond (a: 1) { (:a, :b: 0): a + b, _: -1 }`)
+	AssertCodesEvalToSameValue(t, `-1`, `cond (c: 1) { (:a, :b: 0): a + b, _: -1 }`)
+}
+
+// TestEvalCondKindRefinement tests the `::kind name` control-var patterns,
+// which dispatch on the runtime kind of the control var.
+func TestEvalCondKindRefinement(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `43`, `cond 42 {::number n: n + 1, ::string s: s, _: "other"}`)
+	AssertCodesEvalToSameValue(t, `"hi"`, `cond "hi" {::number n: n + 1, ::string s: s, _: "other"}`)
+	AssertCodesEvalToSameValue(t, `"other"`, `cond [1, 2] {::number n: n + 1, ::string s: s, _: "other"}`)
+	AssertCodesEvalToSameValue(t, `[1, 2, 3]`, `cond [1, 2] {::array a: a ++ [3], ::number n: n, _: "other"}`)
+	AssertCodesEvalToSameValue(t, `<<'a'>>`, `cond <<'a'>> {::bytes b: b, _: "other"}`)
+	AssertCodesEvalToSameValue(t, `(x: 1)`, `cond (x: 1) {::tuple t: t, _: "other"}`)
+	AssertCodesEvalToSameValue(t, `{1, 2}`, `cond {1, 2} {::set s: s, ::array a: a, _: "other"}`)
+
+	_, err := mustCompileWith(t, ParseContext{}, `cond 42 {::widget w: w, _: "other"}`)
+	if err == nil {
+		t.Fatal("expected a compile error for an unknown kind pattern")
+	}
+	if got, want := err.Error(), `unknown kind pattern: "widget"`; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
 }
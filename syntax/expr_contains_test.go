@@ -0,0 +1,33 @@
+package syntax
+
+import (
+	"testing"
+)
+
+func TestContainsStringSubstring(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `true`, `"hello world" contains "world"`)
+	AssertCodesEvalToSameValue(t, `false`, `"hello world" contains "xyz"`)
+}
+
+func TestContainsArrayElement(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `true`, `[1, 2, 3] contains 2`)
+	AssertCodesEvalToSameValue(t, `false`, `[1, 2, 3] contains 9`)
+}
+
+func TestContainsSetElement(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `true`, `{1, 2, 3} contains 2`)
+	AssertCodesEvalToSameValue(t, `false`, `{1, 2, 3} contains 9`)
+}
+
+func TestContainsKindMismatchIsError(t *testing.T) {
+	t.Parallel()
+
+	AssertCodeErrors(t, `contains: a string can only contain a string, not *rel.GenericTuple`,
+		`"hello" contains (a: 1)`)
+}
@@ -0,0 +1,19 @@
+package syntax
+
+import "testing"
+
+func TestExprConvertPostfix(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"42"`, `42::string`)
+	AssertCodesEvalToSameValue(t, `3.5`, `"3.5"::number`)
+	AssertCodesEvalToSameValue(t, `<<'a', 'b', 'c'>>`, `"abc"::bytes`)
+
+	_, err := mustCompileWith(t, ParseContext{}, `42::widget`)
+	if err == nil {
+		t.Fatal("expected a compile error for an unsupported conversion target")
+	}
+	if got, want := err.Error(), `unsupported conversion target: "widget"`; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
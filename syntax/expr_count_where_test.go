@@ -0,0 +1,77 @@
+package syntax
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/arr-ai/arrai/rel"
+)
+
+// TestCountWhereMatchesWhereCountComposition checks that the fused
+// `s countWhere pred` agrees with the unfused `(s where pred) count`.
+func TestCountWhereMatchesWhereCountComposition(t *testing.T) {
+	t.Parallel()
+
+	s := `{1, 2, 3, 4, 5, 6, 7, 8, 9}`
+	AssertCodesEvalToSameValue(t, `(`+s+` where \x x % 2 = 0) count`, s+` countWhere \x x % 2 = 0`)
+	AssertCodesEvalToSameValue(t, `4`, s+` countWhere \x x % 2 = 0`)
+}
+
+// TestCountWhereNoMatches checks that countWhere returns 0, not an error,
+// when no elements satisfy pred.
+func TestCountWhereNoMatches(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `0`, `{1, 2, 3} countWhere \x x > 10`)
+}
+
+// buildCountWhereBenchmarkSet returns a literal set of n numbers, half of
+// which are even, for BenchmarkCountWhere.
+func buildCountWhereBenchmarkSet(n int) string {
+	var sb strings.Builder
+	sb.WriteString("{")
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "%d", i)
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// BenchmarkCountWhere compares the fused `s countWhere pred`, which counts
+// in a single pass, against the unfused `(s where pred) count`, which
+// materializes the filtered set before counting it.
+func BenchmarkCountWhere(b *testing.B) {
+	s := buildCountWhereBenchmarkSet(10000)
+
+	b.Run("Fused", func(b *testing.B) {
+		pc := ParseContext{}
+		expr, err := mustCompileWith(&testing.T{}, pc, s+` countWhere \x x % 2 = 0`)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := expr.(rel.Expr).Eval(rel.EmptyScope); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("WhereThenCount", func(b *testing.B) {
+		pc := ParseContext{}
+		expr, err := mustCompileWith(&testing.T{}, pc, `(`+s+` where \x x % 2 = 0) count`)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := expr.(rel.Expr).Eval(rel.EmptyScope); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,38 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVDecodeHeadered(t *testing.T) {
+	t.Parallel()
+	AssertCodesEvalToSameValue(t,
+		`{(a: "1", b: "2"), (a: "3", b: "4")}`,
+		`csvDecode("a,b\n1,2\n3,4\n", true, ",")`)
+}
+
+func TestCSVDecodeHeaderless(t *testing.T) {
+	t.Parallel()
+	AssertCodesEvalToSameValue(t,
+		`{(@0: "1", @1: "2"), (@0: "3", @1: "4")}`,
+		`csvDecode("1,2\n3,4\n", false, ",")`)
+}
+
+func TestCSVDecodeCustomDelimiter(t *testing.T) {
+	t.Parallel()
+	AssertCodesEvalToSameValue(t,
+		`{(a: "1", b: "2")}`,
+		`csvDecode("a;b\n1;2\n", true, ";")`)
+}
+
+func TestCSVDecodeRaggedRowIsCatchable(t *testing.T) {
+	t.Parallel()
+	_, err := EvaluateExpr("", `csvDecode("a,b\n1,2,3\n", true, ",")`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "line 2")
+
+	AssertCodesEvalToSameValue(t, `"caught"`,
+		`try csvDecode("a,b\n1,2,3\n", true, ",") catch csvDecode "caught"`)
+}
@@ -0,0 +1,43 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/arr-ai/arrai/rel"
+)
+
+// TestDebugTapsAndPassesThrough checks `debug(label, expr)`: it reports
+// label and expr's evaluated value to ParseContext.DebugSink, then returns
+// the value unchanged, acting as an identity tap.
+func TestDebugTapsAndPassesThrough(t *testing.T) {
+	t.Parallel()
+
+	var labels []string
+	var values []rel.Value
+	pc := ParseContext{DebugSink: func(label string, value rel.Value) {
+		labels = append(labels, label)
+		values = append(values, value)
+	}}
+
+	b, err := pc.Parse(parser.NewScanner(`debug("sum", 1 + 2)`))
+	require.NoError(t, err)
+	value, err := pc.CompileExpr(b).Eval(rel.Scope{})
+	require.NoError(t, err)
+
+	assert.Equal(t, rel.NewNumber(3), value)
+	assert.Equal(t, []string{"sum"}, labels)
+	require.Len(t, values, 1)
+	assert.Equal(t, rel.NewNumber(3), values[0])
+}
+
+// TestDebugWithoutSinkStillPassesThrough checks that `debug` with no
+// DebugSink configured is still a transparent identity tap.
+func TestDebugWithoutSinkStillPassesThrough(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `3`, `debug("sum", 1 + 2)`)
+}
@@ -0,0 +1,28 @@
+package syntax
+
+import "testing"
+
+// TestDedentStripsUniformIndentation checks that a common indentation
+// shared by every line is removed entirely.
+func TestDedentStripsUniformIndentation(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"a\nb\nc"`, `dedent("    a\n    b\n    c")`)
+}
+
+// TestDedentStripsOnlyCommonPrefixOfMixedIndentation checks that with
+// mixed indentation, only the shortest common leading whitespace is
+// stripped, leaving relative indentation intact.
+func TestDedentStripsOnlyCommonPrefixOfMixedIndentation(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"a\n  b\nc"`, `dedent("    a\n      b\n    c")`)
+}
+
+// TestDedentIgnoresBlankLines checks that blank lines don't affect the
+// computed common prefix and are left unchanged.
+func TestDedentIgnoresBlankLines(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"a\n\nb"`, `dedent("    a\n\n    b")`)
+}
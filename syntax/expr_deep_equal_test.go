@@ -0,0 +1,28 @@
+package syntax
+
+import (
+	"testing"
+)
+
+func TestApproxEqualNearFloatsPass(t *testing.T) {
+	t.Parallel()
+	AssertCodesEvalToSameValue(t, `true`, `approxEqual(0.1 + 0.2, 0.3, 0.0001)`)
+	AssertCodesEvalToSameValue(t, `false`, `0.1 + 0.2 = 0.3`)
+}
+
+func TestApproxEqualRecursesThroughTuplesArraysSets(t *testing.T) {
+	t.Parallel()
+	AssertCodesEvalToSameValue(t, `true`,
+		`approxEqual((a: 0.1 + 0.2, b: [1, 1.999999999]), (a: 0.3, b: [1, 2]), 0.0001)`)
+	AssertCodesEvalToSameValue(t, `true`,
+		`approxEqual({1, 2, 3}, {3, 2, 1.0000001}, 0.001)`)
+}
+
+func TestApproxEqualClearlyDifferentValuesFail(t *testing.T) {
+	t.Parallel()
+	AssertCodesEvalToSameValue(t, `false`, `approxEqual(1, 2, 0.0001)`)
+	AssertCodesEvalToSameValue(t, `false`,
+		`approxEqual((a: 1, b: 2), (a: 1, b: 3), 0.0001)`)
+	AssertCodesEvalToSameValue(t, `false`, `approxEqual([1, 2], [1, 2, 3], 0.0001)`)
+	AssertCodesEvalToSameValue(t, `false`, `approxEqual("ab", "ba", 0.0001)`)
+}
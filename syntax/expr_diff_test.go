@@ -0,0 +1,38 @@
+package syntax
+
+import (
+	"testing"
+)
+
+func TestDiffEqualValuesIsEmpty(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `{}`, `diff((a: 1, b: (c: 2, d: 3)), (a: 1, b: (c: 2, d: 3)))`)
+}
+
+func TestDiffNestedTupleOneChangedLeaf(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t,
+		`{(path: ['b', 'd'], kind: 'changed', a: 3, b: 4)}`,
+		`diff((a: 1, b: (c: 2, d: 3)), (a: 1, b: (c: 2, d: 4)))`,
+	)
+}
+
+func TestDiffAddedAndRemovedAttrs(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t,
+		`{(path: ['old'], kind: 'removed', a: 1), (path: ['new'], kind: 'added', b: 2)}`,
+		`diff((old: 1), (new: 2))`,
+	)
+}
+
+func TestDiffArraysByIndex(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t,
+		`{(path: ['1'], kind: 'changed', a: 2, b: 20), (path: ['2'], kind: 'added', b: 3)}`,
+		`diff([1, 2], [1, 20, 3])`,
+	)
+}
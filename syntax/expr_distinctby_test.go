@@ -0,0 +1,23 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistinctByKeepsFirstPerKey(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t,
+		`[(id: 1, v: "a"), (id: 2, v: "b")]`,
+		`[(id: 1, v: "a"), (id: 2, v: "b"), (id: 1, v: "c")] distinctby \x x.id`)
+}
+
+func TestDistinctByOnUnorderedSetErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := EvaluateExpr("", `{(id: 1, v: "a"), (id: 2, v: "b")} distinctby \x x.id`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "'distinctby' lhs must be an ordered Array")
+}
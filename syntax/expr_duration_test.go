@@ -0,0 +1,33 @@
+package syntax
+
+import (
+	"testing"
+)
+
+func TestDurationParsesUnits(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `3600e9`, `duration("1h")`)
+	AssertCodesEvalToSameValue(t, `1800e9`, `duration("30m")`)
+	AssertCodesEvalToSameValue(t, `500e6`, `duration("500ms")`)
+}
+
+func TestAddDurationAddsDurationToTime(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `1700003600`, `addDuration(1700000000, duration("1h"))`)
+}
+
+func TestTimeDiffSubtractsTimesToGetDuration(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `3600e9`, `timeDiff(1700003600, 1700000000)`)
+}
+
+func TestDurationComparison(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `true`, `duration("1h") > duration("30m")`)
+	AssertCodesEvalToSameValue(t, `false`, `duration("30m") > duration("1h")`)
+	AssertCodesEvalToSameValue(t, `true`, `duration("1h") = duration("60m")`)
+}
@@ -0,0 +1,67 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/arr-ai/arrai/rel"
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func envLookupFromMap(env map[string]string) func(name string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+}
+
+func TestEnvReturnsPresentVariable(t *testing.T) {
+	t.Parallel()
+
+	pc := ParseContext{EnvLookup: envLookupFromMap(map[string]string{"HOME": "/home/arrai"})}
+
+	ast, err := pc.Parse(parser.NewScanner(`env("HOME", "default")`))
+	require.NoError(t, err)
+	value, err := pc.CompileExpr(ast).Eval(rel.Scope{})
+	require.NoError(t, err)
+
+	assert.Equal(t, rel.NewString([]rune("/home/arrai")), value)
+}
+
+func TestEnvFallsBackToDefaultWhenMissing(t *testing.T) {
+	t.Parallel()
+
+	pc := ParseContext{EnvLookup: envLookupFromMap(map[string]string{})}
+
+	ast, err := pc.Parse(parser.NewScanner(`env("MISSING", "default")`))
+	require.NoError(t, err)
+	value, err := pc.CompileExpr(ast).Eval(rel.Scope{})
+	require.NoError(t, err)
+
+	assert.Equal(t, rel.NewString([]rune("default")), value)
+}
+
+func TestEnvMissingWithoutDefaultIsCatchable(t *testing.T) {
+	t.Parallel()
+
+	pc := ParseContext{EnvLookup: envLookupFromMap(map[string]string{})}
+
+	ast, err := pc.Parse(parser.NewScanner(`try env("MISSING") catch env "caught"`))
+	require.NoError(t, err)
+	value, err := pc.CompileExpr(ast).Eval(rel.Scope{})
+	require.NoError(t, err)
+
+	assert.Equal(t, rel.NewString([]rune("caught")), value)
+}
+
+func TestEnvDisallowedByCapability(t *testing.T) {
+	t.Parallel()
+
+	_, err := mustCompileWith(t, ParseContext{DisallowAmbientEnv: true}, `env("HOME", "default")`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ambient environment access not permitted")
+
+	_, err = mustCompileWith(t, ParseContext{}, `env("HOME", "default")`)
+	assert.NoError(t, err)
+}
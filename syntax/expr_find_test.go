@@ -0,0 +1,39 @@
+package syntax
+
+import "testing"
+
+// TestFindReturnsFirstMatchingArrayElement checks that `a find(pred)`
+// returns the first Array element (by index) satisfying pred.
+func TestFindReturnsFirstMatchingArrayElement(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `2`, `([1, 2, 3] find(\x x.@item > 1)).@item`)
+}
+
+// TestFindNoMatchReturnsFallback checks that `a find(pred, fallback)`
+// evaluates to fallback when no element satisfies pred.
+func TestFindNoMatchReturnsFallback(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"none"`, `[1, 2, 3] find(\x x.@item > 10, "none")`)
+}
+
+// TestFindNoMatchNoFallbackIsNone checks that `a find(pred)`, with no
+// fallback supplied, evaluates to None (the empty Set) when no element
+// satisfies pred.
+func TestFindNoMatchNoFallbackIsNone(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `true`, `([1, 2, 3] find(\x x.@item > 10)) = {}`)
+}
+
+// TestFindShortCircuitsOnFirstMatch checks that find stops evaluating once
+// a satisfying element is found: the predicate errors on any later element,
+// but since the first element already satisfies it, that later error is
+// never reached.
+func TestFindShortCircuitsOnFirstMatch(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `1`,
+		`([1, 2, 3] find(\x x.@item = 1 || (x.@item).z)) .@item`)
+}
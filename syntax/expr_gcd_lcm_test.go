@@ -0,0 +1,35 @@
+package syntax
+
+import (
+	"testing"
+)
+
+func TestGCDTypicalPairs(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `6`, `gcd(12, 18)`)
+	AssertCodesEvalToSameValue(t, `1`, `gcd(7, 13)`)
+}
+
+func TestLCMTypicalPairs(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `12`, `lcm(4, 6)`)
+	AssertCodesEvalToSameValue(t, `91`, `lcm(7, 13)`)
+}
+
+func TestGCDLCMZeroCases(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `0`, `gcd(0, 0)`)
+	AssertCodesEvalToSameValue(t, `0`, `lcm(0, 0)`)
+	AssertCodesEvalToSameValue(t, `5`, `gcd(0, 5)`)
+	AssertCodesEvalToSameValue(t, `0`, `lcm(0, 5)`)
+}
+
+func TestGCDLCMNonIntegerIsError(t *testing.T) {
+	t.Parallel()
+
+	AssertCodeErrors(t, `gcd: a must be an integral number, not 1.5`, `gcd(1.5, 2)`)
+	AssertCodeErrors(t, `lcm: b must be an integral number, not 2.5`, `lcm(2, 2.5)`)
+}
@@ -0,0 +1,43 @@
+package syntax
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/arr-ai/arrai/rel"
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenIDWithFixedSeedIsStable(t *testing.T) {
+	t.Parallel()
+
+	pc := ParseContext{IDGenerator: func(seed int64) string {
+		return fmt.Sprintf("id-%d", seed)
+	}}
+
+	ast, err := pc.Parse(parser.NewScanner(`genid(42)`))
+	require.NoError(t, err)
+	value, err := pc.CompileExpr(ast).Eval(rel.Scope{})
+	require.NoError(t, err)
+
+	assert.Equal(t, rel.NewString([]rune("id-42")), value)
+}
+
+func TestGenIDSameSeedProducesSameID(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `genid(42)`, `genid(42)`)
+}
+
+func TestGenIDDisallowedByCapability(t *testing.T) {
+	t.Parallel()
+
+	_, err := mustCompileWith(t, ParseContext{DisallowAmbientRandomness: true}, `genid(42)`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ambient randomness not permitted")
+
+	_, err = mustCompileWith(t, ParseContext{}, `genid(42)`)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,56 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/arr-ai/arrai/rel"
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlobListsMatchingPathsSorted(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "b.arrai", []byte(""), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "a.arrai", []byte(""), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "c.json", []byte(""), 0o644))
+
+	pc := ParseContext{FS: fs}
+
+	ast, err := pc.Parse(parser.NewScanner(`glob("*.arrai")`))
+	require.NoError(t, err)
+	value, err := pc.CompileExpr(ast).Eval(rel.Scope{})
+	require.NoError(t, err)
+
+	assert.Equal(t, rel.NewArray(rel.NewString([]rune("a.arrai")), rel.NewString([]rune("b.arrai"))), value)
+}
+
+func TestGlobNoMatchesIsEmptyArray(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "a.arrai", []byte(""), 0o644))
+
+	pc := ParseContext{FS: fs}
+
+	ast, err := pc.Parse(parser.NewScanner(`glob("*.json")`))
+	require.NoError(t, err)
+	value, err := pc.CompileExpr(ast).Eval(rel.Scope{})
+	require.NoError(t, err)
+
+	assert.Equal(t, rel.NewArray(), value)
+}
+
+func TestGlobDisallowedByCapability(t *testing.T) {
+	t.Parallel()
+
+	_, err := mustCompileWith(t, ParseContext{DisallowAmbientFS: true}, `glob("*.arrai")`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ambient filesystem access not permitted")
+
+	_, err = mustCompileWith(t, ParseContext{}, `glob("*.arrai")`)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,23 @@
+package syntax
+
+import "testing"
+
+func TestHashOfEqualSetsMatchesRegardlessOfConstructionOrder(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `true`, `hash({1, 2, 3}) = hash({3, 2, 1})`)
+	AssertCodesEvalToSameValue(t, `true`, `hash((a: 1, b: 2)) = hash((b: 2, a: 1))`)
+}
+
+func TestHashOfUnequalValuesDiffers(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `false`, `hash({1, 2, 3}) = hash({1, 2, 4})`)
+	AssertCodesEvalToSameValue(t, `false`, `hash("a") = hash("b")`)
+}
+
+func TestHashIsDeterministicAcrossCompilations(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `hash({1, 2, 3})`, `hash({1, 2, 3})`)
+}
@@ -0,0 +1,19 @@
+package syntax
+
+import (
+	"testing"
+)
+
+func TestHistogramCountsByKey(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `{'a': 3, 'b': 1}`,
+		`{(id: 1, category: "a"), (id: 2, category: "b"), (id: 3, category: "a"), (id: 4, category: "a")}
+			histogram \t t.category`)
+}
+
+func TestHistogramOfEmptyRelationIsEmptyDict(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `{}`, `{} histogram \t t.category`)
+}
@@ -0,0 +1,26 @@
+package syntax
+
+import "testing"
+
+// TestHmacSha256KnownVector checks hmacSha256 against RFC 4231 test case 1:
+// key = 20 bytes of 0x0b, message = "Hi There".
+func TestHmacSha256KnownVector(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(
+		t,
+		`"b0344c61d8db38535ca8afceaf0bf12b881dc200c9833da726e9376c2e32cff7"`,
+		`hmacSha256(<<11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11>>, "Hi There")`,
+	)
+}
+
+// TestHmacSha256EmptyKey checks hmacSha256 with an empty key.
+func TestHmacSha256EmptyKey(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(
+		t,
+		`"5f576a8d68fe4fb7eb823227246353c0870c3b0e878997341db1226b4bd88d61"`,
+		`hmacSha256("", "msg")`,
+	)
+}
@@ -0,0 +1,12 @@
+package syntax
+
+import "testing"
+
+func TestIfLet(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `1`, `if let (:x) = (x: 1) { x } else { 0 }`)
+	AssertCodesEvalToSameValue(t, `0`, `if let (:x) = (y: 1) { x } else { 0 }`)
+	AssertCodesEvalToSameValue(t, `{}`, `if let (:x) = (y: 1) { x }`)
+	AssertCodesEvalToSameValue(t, `2`, `if let x = 1 { x + 1 }`)
+}
@@ -0,0 +1,35 @@
+package syntax
+
+import "testing"
+
+// TestIndentPrependsPrefixToEachLine checks that prefix is prepended to
+// every non-empty line.
+func TestIndentPrependsPrefixToEachLine(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"> a\n> b"`, `indent("a\nb", "> ")`)
+}
+
+// TestIndentPreservesTrailingNewline checks that a trailing newline in s
+// doesn't produce a spurious indented empty final line.
+func TestIndentPreservesTrailingNewline(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"> a\n> b\n"`, `indent("a\nb\n", "> ")`)
+}
+
+// TestIndentSkipsEmptyLinesByDefault checks that empty lines are left
+// alone when all is omitted.
+func TestIndentSkipsEmptyLinesByDefault(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"> a\n\n> b"`, `indent("a\n\nb", "> ")`)
+}
+
+// TestIndentAllPrependsEveryLine checks that passing all=true prepends
+// prefix to empty lines too.
+func TestIndentAllPrependsEveryLine(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"> a\n> \n> b"`, `indent("a\n\nb", "> ", true)`)
+}
@@ -0,0 +1,52 @@
+package syntax
+
+import "testing"
+
+// TestIndexOfArrayFoundAtStart checks that indexOf finds an Array element
+// at index 0.
+func TestIndexOfArrayFoundAtStart(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `0`, `indexOf([10, 20, 30], 10)`)
+}
+
+// TestIndexOfArrayFoundInMiddle checks that indexOf finds an Array element
+// at a non-zero index.
+func TestIndexOfArrayFoundInMiddle(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `1`, `indexOf([10, 20, 30], 20)`)
+}
+
+// TestIndexOfArrayNotFound checks that indexOf returns -1, not an error or
+// None, when the Array has no matching element.
+func TestIndexOfArrayNotFound(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `-1`, `indexOf([10, 20, 30], 99)`)
+}
+
+// TestIndexOfStringFoundAtStart checks that indexOf finds a substring at
+// the start of a String.
+func TestIndexOfStringFoundAtStart(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `0`, `indexOf("hello world", "hello")`)
+}
+
+// TestIndexOfStringFoundInMiddle checks that indexOf finds a substring
+// starting partway through a String, counting by rune, not byte, offset.
+func TestIndexOfStringFoundInMiddle(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `6`, `indexOf("hello world", "world")`)
+	AssertCodesEvalToSameValue(t, `2`, `indexOf("日本語です", "語")`)
+}
+
+// TestIndexOfStringNotFound checks that indexOf returns -1, not an error or
+// None, when the substring does not occur.
+func TestIndexOfStringNotFound(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `-1`, `indexOf("hello world", "xyz")`)
+}
@@ -0,0 +1,36 @@
+package syntax
+
+import (
+	"testing"
+)
+
+func TestInterleaveEqualLengthArrays(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `[1, 10, 2, 20, 3, 30]`, `interleave([1, 2, 3], [10, 20, 30])`)
+}
+
+func TestInterleaveUnequalLengthArraysContinuesWithRemainder(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `[1, 10, 2, 20, 3]`, `interleave([1, 2, 3], [10, 20])`)
+	AssertCodesEvalToSameValue(t, `[1, 10, 2, 20, 30]`, `interleave([1, 2], [10, 20, 30])`)
+}
+
+func TestInterleaveSkipsEmptyArrays(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `[1, 2]`, `interleave([], [1, 2])`)
+}
+
+func TestInterleaveThreeArrays(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `[1, 2, 3, 4, 5, 6]`, `interleave([1, 4], [2, 5], [3, 6])`)
+}
+
+func TestInterleaveNonArrayIsError(t *testing.T) {
+	t.Parallel()
+
+	AssertCodeErrors(t, `interleave: argument must be an Array, not rel.Number`, `interleave(1, [2])`)
+}
@@ -0,0 +1,31 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJoinTypeMismatchNamesAttrs(t *testing.T) {
+	t.Parallel()
+
+	_, err := EvaluateExpr("", `{(x: 1, y: 2)} <&> 5`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "attrs [x y]")
+}
+
+func TestJoinTypeMismatchWithoutKnownSchemaOmitsAttrs(t *testing.T) {
+	t.Parallel()
+
+	_, err := EvaluateExpr("", `let a = 5; let b = 6; a <&> b`)
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), "attrs")
+}
+
+func TestJoinStillWorks(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t,
+		`{(x: 1, y: 2, z: 3)}`,
+		`{(x: 1, y: 2)} <&> {(y: 2, z: 3)}`)
+}
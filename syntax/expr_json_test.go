@@ -0,0 +1,31 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONDecodeEncodeRoundTripNestedObject(t *testing.T) {
+	t.Parallel()
+	AssertCodesEvalToSameValue(t,
+		`(a: (b: [1, "x", true], c: "y"))`,
+		`jsonDecode('{"a": {"b": [1, "x", true], "c": "y"}}')`)
+
+	source := `'{"a":{"b":[1,"x",true],"c":"y"}}'`
+	AssertCodesEvalToSameValue(t, source, `jsonEncode(jsonDecode(`+source+`))`)
+}
+
+func TestJSONDecodeNullIsNone(t *testing.T) {
+	t.Parallel()
+	AssertCodesEvalToSameValue(t, `{}`, `jsonDecode("null")`)
+}
+
+func TestJSONDecodeMalformedInputIsCatchable(t *testing.T) {
+	t.Parallel()
+	_, err := EvaluateExpr("", `jsonDecode("{bad json")`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "offset")
+
+	AssertCodesEvalToSameValue(t, `"caught"`, `try jsonDecode("{bad json") catch jsonDecode "caught"`)
+}
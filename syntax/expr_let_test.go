@@ -25,6 +25,18 @@ func TestExprLet(t *testing.T) { //nolint:dupl
 	AssertCodeErrors(t, "", `let (x) = 5;(x)`)
 }
 
+func TestExprLetMulti(t *testing.T) {
+	t.Parallel()
+	AssertCodesEvalToSameValue(t, `3`, `let a = 1, b = 2; a + b`)
+	AssertCodesEvalToSameValue(t, `3`, `let a = 1, b = a + 1; a + b`)
+	AssertCodesEvalToSameValue(t, `6`, `let a = 1, b = a + 1, c = a + b + 3; c`)
+	AssertCodesEvalToSameValue(t, `[1, 2]`, `let [a, b] = [1, 2], c = a + b; [a, b]`)
+	AssertCodesEvalToSameValue(t, `3`, `let a = 1; let b = 2, c = a + b; c`)
+
+	AssertCodePanics(t, `let a = b + 1, b = 2; a`)
+	AssertCodePanics(t, `let a = 1, b = c, c = 2; a + b + c`)
+}
+
 func TestExprLetExprPattern(t *testing.T) { //nolint:dupl
 	t.Parallel()
 	AssertCodesEvalToSameValue(t, `42`, `let 42 = 42; 42`)
@@ -0,0 +1,23 @@
+package syntax
+
+import "testing"
+
+// TestExprMatches tests the `value ~ pattern` structural pattern test,
+// which is true iff pattern matches value, discarding any bindings.
+func TestExprMatches(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `true`, `(a: 1, b: 2) ~ (a: _, b: _)`)
+	AssertCodesEvalToSameValue(t, `false`, `(a: 1, b: 2) ~ (a: _, c: _)`)
+	AssertCodesEvalToSameValue(t, `true`, `(a: 1, b: 2) ~ (a: 1, b: _)`)
+	AssertCodesEvalToSameValue(t, `false`, `(a: 1, b: 2) ~ (a: 2, b: _)`)
+
+	// Bindings introduced by the pattern are discarded: matching doesn't
+	// shadow the outer x, even though the pattern also binds a name x.
+	AssertCodesEvalToSameValue(t, `[true, 99]`, `let x = 99; [(x: 1) ~ (x: x), x]`)
+
+	AssertCodesEvalToSameValue(t,
+		`{(a: 3, b: 41)}`,
+		`{(a: 3, b: 41), (a: 2, b: 42)} where \t t ~ (a: 3, b: _)`,
+	)
+}
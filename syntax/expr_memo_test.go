@@ -0,0 +1,40 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/arr-ai/arrai/rel"
+)
+
+// TestMemoCachesAcrossRepeatedReferences checks that `memo(body)` only
+// evaluates body once, however many times the memo node is referenced,
+// by wrapping an "expensive" expr in debug(...) and counting taps via
+// ParseContext.DebugSink.
+func TestMemoCachesAcrossRepeatedReferences(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	pc := ParseContext{DebugSink: func(label string, value rel.Value) {
+		calls++
+	}}
+
+	b, err := pc.Parse(parser.NewScanner(
+		`let expensive = memo(debug("compute", 1 + 2)); expensive + expensive`,
+	))
+	require.NoError(t, err)
+	value, err := pc.CompileExpr(b).Eval(rel.Scope{})
+	require.NoError(t, err)
+
+	assert.Equal(t, rel.NewNumber(6), value)
+	assert.Equal(t, 1, calls)
+}
+
+func TestMemoWithoutReuseStillEvaluates(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `3`, `memo(1 + 2)`)
+}
@@ -0,0 +1,29 @@
+package syntax
+
+import (
+	"testing"
+)
+
+func TestMergeDeepMergesOverlappingNestedSections(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t,
+		`(db: (host: 'a', port: 2, user: 'u'), x: 1, y: 2)`,
+		`mergeDeep((db: (host: "a", port: 1), x: 1), (db: (port: 2, user: "u"), y: 2))`,
+	)
+}
+
+func TestMergeDeepNonTupleConflictTakesB(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `(a: [3, 4])`, `mergeDeep((a: [1, 2]), (a: [3, 4]))`)
+}
+
+func TestMergeDeepDiffersFromShallowTupleMerge(t *testing.T) {
+	t.Parallel()
+
+	// `+` replaces the nested tuple wholesale, dropping "b".
+	AssertCodesEvalToSameValue(t, `(a: (c: 2))`, `(a: (b: 1)) + (a: (c: 2))`)
+	// mergeDeep merges the nested tuple instead.
+	AssertCodesEvalToSameValue(t, `(a: (b: 1, c: 2))`, `mergeDeep((a: (b: 1)), (a: (c: 2)))`)
+}
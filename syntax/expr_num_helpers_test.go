@@ -0,0 +1,29 @@
+package syntax
+
+import (
+	"testing"
+)
+
+func TestAbsNegativeZeroPositive(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `5`, `abs(-5)`)
+	AssertCodesEvalToSameValue(t, `0`, `abs(0)`)
+	AssertCodesEvalToSameValue(t, `5`, `abs(5)`)
+}
+
+func TestSignNegativeZeroPositive(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `-1`, `sign(-5)`)
+	AssertCodesEvalToSameValue(t, `0`, `sign(0)`)
+	AssertCodesEvalToSameValue(t, `1`, `sign(5)`)
+}
+
+func TestPow10NegativeZeroPositive(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `0.01`, `pow10(-2)`)
+	AssertCodesEvalToSameValue(t, `1`, `pow10(0)`)
+	AssertCodesEvalToSameValue(t, `1000`, `pow10(3)`)
+}
@@ -0,0 +1,35 @@
+package syntax
+
+import "testing"
+
+func TestOffsetOperatorPositive(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `1`, `(5 \ [1, 2, 3])(5)`)
+	AssertCodesEvalToSameValue(t, `3`, `(5 \ [1, 2, 3])(7)`)
+}
+
+func TestOffsetOperatorNegative(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `1`, `((-2) \ [1, 2, 3])(-2)`)
+	AssertCodesEvalToSameValue(t, `3`, `((-2) \ [1, 2, 3])(0)`)
+}
+
+func TestOffsetOperatorZeroIsNoOp(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `[1, 2, 3]`, `0 \ [1, 2, 3]`)
+}
+
+func TestOffsetOperatorConcatenation(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `3 \ [9, 9, 1, 2, 3]`, `(5 \ [1, 2, 3]) ++ [9, 9]`)
+}
+
+func TestOffsetOperatorString(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `97`, `((-3) \ "abc")(-3)`)
+}
@@ -22,3 +22,13 @@ func TestRank(t *testing.T) {
 	AssertCodesEvalToSameValue(t, `{|x,r| (1,2), (2,1), (3,0)}`, `{|x| (1), (2), (3)} rank (r: -.x)`)
 	AssertCodesEvalToSameValue(t, `{|x,r,s| (1,0,2), (2,1,1), (3,2,0)}`, `{|x| (1), (2), (3)} rank (r: .x, s: -.x)`)
 }
+
+func TestOrderByInconsistentKeyKinds(t *testing.T) {
+	t.Parallel()
+	AssertCodeErrors(t, "key values must be of a consistent type", `{1, "two", 3} orderby .`)
+}
+
+func TestRankInconsistentKeyKinds(t *testing.T) {
+	t.Parallel()
+	AssertCodeErrors(t, "key values must be of a consistent type", `{|x| (1), ("two"), (3)} rank (r: .x)`)
+}
@@ -0,0 +1,34 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPadLeftShorterString(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"005"`, `padleft("5", 3, "0")`)
+}
+
+func TestPadRightShorterString(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"500"`, `padright("5", 3, "0")`)
+}
+
+func TestPadLeavesLongerStringUnchanged(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"12345"`, `padleft("12345", 3, "0")`)
+	AssertCodesEvalToSameValue(t, `"12345"`, `padright("12345", 3, "0")`)
+}
+
+func TestPadMultiRuneFillIsCompileError(t *testing.T) {
+	t.Parallel()
+
+	_, err := Compile(NoPath, `padleft("5", 3, "ab")`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "single-rune")
+}
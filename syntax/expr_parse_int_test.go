@@ -0,0 +1,29 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNumDecimal(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `42`, `parseNum("42", 10)`)
+}
+
+func TestParseNumHexAutoDetect(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `42`, `parseNum("0x2a", 0)`)
+}
+
+func TestParseNumMalformedInputIsCatchable(t *testing.T) {
+	t.Parallel()
+
+	_, err := EvaluateExpr("", `parseNum("not a number", 10)`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "parseNum")
+
+	AssertCodesEvalToSameValue(t, `-1`, `try parseNum("not a number", 10) catch parseNum -1`)
+}
@@ -0,0 +1,31 @@
+package syntax
+
+import (
+	"testing"
+)
+
+func TestPartitionArrayOfNumbersIntoEvensAndOddsOrderPreserved(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `(yes: [2, 4, 6], no: [1, 3, 5])`,
+		`[1, 2, 3, 4, 5, 6] partition \x x % 2 = 0`)
+}
+
+func TestPartitionSet(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `(yes: {2, 4}, no: {1, 3})`,
+		`{1, 2, 3, 4} partition \x x % 2 = 0`)
+}
+
+func TestPartitionOfEmptyArrayIsEmptyArrays(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `(yes: [], no: [])`, `[] partition \x x % 2 = 0`)
+}
+
+func TestPartitionNonSetLhsIsError(t *testing.T) {
+	t.Parallel()
+
+	AssertCodeErrors(t, `'partition' lhs must be a Set, not rel.Number`, `1 partition \x x`)
+}
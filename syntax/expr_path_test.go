@@ -0,0 +1,38 @@
+package syntax
+
+import (
+	"testing"
+)
+
+func TestGetPathDeepGet(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `2`, `getPath((a: (b: [1, 2, 3])), ["a", "b", 1])`)
+}
+
+func TestSetPathDeepSet(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t,
+		`(a: (b: [1, 99, 3]))`,
+		`setPath((a: (b: [1, 2, 3])), ["a", "b", 1], 99)`,
+	)
+}
+
+func TestGetPathMissingAttributeIsCatchable(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"caught"`, `try getPath((a: 1), ["z"]) catch getPath "caught"`)
+}
+
+func TestSetPathMissingAttributeIsCatchable(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"caught"`, `try setPath((a: 1), ["z"], 2) catch setPath "caught"`)
+}
+
+func TestGetPathOutOfRangeIndexIsCatchable(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"caught"`, `try getPath([1, 2], [5]) catch getPath "caught"`)
+}
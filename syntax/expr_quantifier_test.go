@@ -0,0 +1,57 @@
+package syntax
+
+import "testing"
+
+// TestAnyFindsSatisfyingElement checks that `s any pred` is true when some
+// element of s satisfies pred.
+func TestAnyFindsSatisfyingElement(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `true`, `{1, 2, 3} any \x x = 2`)
+	AssertCodesEvalToSameValue(t, `false`, `{1, 2, 3} any \x x = 4`)
+}
+
+// TestAllRequiresEverySatisfyingElement checks that `s all pred` is true
+// only when every element of s satisfies pred.
+func TestAllRequiresEverySatisfyingElement(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `true`, `{1, 2, 3} all \x x > 0`)
+	AssertCodesEvalToSameValue(t, `false`, `{1, 2, 3} all \x x > 1`)
+}
+
+// TestAnyOverEmptyIsFalse and TestAllOverEmptyIsTrue check the conventional
+// quantifier results over an empty collection.
+func TestAnyOverEmptyIsFalse(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `false`, `{} any \x x > 0`)
+}
+
+func TestAllOverEmptyIsTrue(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `true`, `{} all \x x > 0`)
+}
+
+// TestAnyShortCircuitsOnFirstTrue checks that `any` stops evaluating once a
+// satisfying element is found: the predicate errors on any later element of
+// the ordered Array it's given, but since the first element already
+// satisfies it, that later error is never reached.
+func TestAnyShortCircuitsOnFirstTrue(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `true`,
+		`[1, 2, 3] any \x x.@item = 1 || (x.@item).z`)
+}
+
+// TestAllShortCircuitsOnFirstFalse checks that `all` stops evaluating once
+// a non-satisfying element is found: the predicate errors on any later
+// element of the ordered Array it's given, but since the first element
+// already fails, that later error is never reached.
+func TestAllShortCircuitsOnFirstFalse(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `false`,
+		`[1, 2, 3] all \x x.@item != 1 && (x.@item).z`)
+}
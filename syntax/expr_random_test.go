@@ -0,0 +1,69 @@
+package syntax
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/arr-ai/arrai/rel"
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRandSameSeedProducesSameValue(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `rand(42)`, `rand(42)`)
+}
+
+func TestShuffleWithFixedSeedIsReproducible(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `shuffle([1, 2, 3, 4, 5], 42)`, `shuffle([1, 2, 3, 4, 5], 42)`)
+}
+
+func TestShuffleIsAPermutation(t *testing.T) {
+	t.Parallel()
+
+	var pc ParseContext
+	ast, err := pc.Parse(parser.NewScanner(`shuffle([1, 2, 3, 4, 5], 42)`))
+	require.NoError(t, err)
+	value, err := pc.CompileExpr(ast).Eval(rel.Scope{})
+	require.NoError(t, err)
+
+	arr, ok := value.(rel.Array)
+	require.True(t, ok)
+
+	var got []int
+	for _, v := range arr.Values() {
+		n, ok := v.(rel.Number)
+		require.True(t, ok)
+		i, ok := n.Int()
+		require.True(t, ok)
+		got = append(got, i)
+	}
+	sort.Ints(got)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, got)
+}
+
+func TestRandDisallowedByCapability(t *testing.T) {
+	t.Parallel()
+
+	_, err := mustCompileWith(t, ParseContext{DisallowAmbientRandomness: true}, `rand(42)`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ambient randomness not permitted")
+
+	_, err = mustCompileWith(t, ParseContext{}, `rand(42)`)
+	assert.NoError(t, err)
+}
+
+func TestShuffleDisallowedByCapability(t *testing.T) {
+	t.Parallel()
+
+	_, err := mustCompileWith(t, ParseContext{DisallowAmbientRandomness: true}, `shuffle([1, 2, 3], 42)`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ambient randomness not permitted")
+
+	_, err = mustCompileWith(t, ParseContext{}, `shuffle([1, 2, 3], 42)`)
+	assert.NoError(t, err)
+}
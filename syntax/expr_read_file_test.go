@@ -0,0 +1,71 @@
+package syntax
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/arr-ai/arrai/rel"
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadFileStrReadsFileContents(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"greeting.txt": {Data: []byte("hello")},
+	}
+	pc := ParseContext{ReadFS: fsys}
+
+	ast, err := pc.Parse(parser.NewScanner(`readFileStr("greeting.txt")`))
+	require.NoError(t, err)
+	value, err := pc.CompileExpr(ast).Eval(rel.Scope{})
+	require.NoError(t, err)
+
+	assert.Equal(t, rel.NewString([]rune("hello")), value)
+}
+
+func TestReadFileReadsFileContentsAsBytes(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"greeting.txt": {Data: []byte("hi")},
+	}
+	pc := ParseContext{ReadFS: fsys}
+
+	ast, err := pc.Parse(parser.NewScanner(`readFile("greeting.txt")`))
+	require.NoError(t, err)
+	value, err := pc.CompileExpr(ast).Eval(rel.Scope{})
+	require.NoError(t, err)
+
+	assert.Equal(t, rel.NewBytes([]byte("hi")), value)
+}
+
+func TestReadFileMissingFileIsCatchable(t *testing.T) {
+	t.Parallel()
+
+	pc := ParseContext{ReadFS: fstest.MapFS{}}
+
+	ast, err := pc.Parse(parser.NewScanner(`try readFileStr("missing.txt") catch readFileStr "caught"`))
+	require.NoError(t, err)
+	value, err := pc.CompileExpr(ast).Eval(rel.Scope{})
+	require.NoError(t, err)
+
+	assert.Equal(t, rel.NewString([]rune("caught")), value)
+}
+
+func TestReadFileDisallowedByCapability(t *testing.T) {
+	t.Parallel()
+
+	_, err := mustCompileWith(t, ParseContext{DisallowAmbientFS: true}, `readFile("greeting.txt")`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ambient filesystem access not permitted")
+
+	_, err = mustCompileWith(t, ParseContext{DisallowAmbientFS: true}, `readFileStr("greeting.txt")`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ambient filesystem access not permitted")
+
+	_, err = mustCompileWith(t, ParseContext{ReadFS: fstest.MapFS{}}, `readFileStr("greeting.txt")`)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,33 @@
+package syntax
+
+import (
+	"testing"
+)
+
+func TestRecordConstructsTupleViaFactory(t *testing.T) {
+	t.Parallel()
+	AssertCodesEvalToSameValue(t,
+		`(x: 1, y: 2)`,
+		`let Point = record(x, y); Point((x: 1, y: 2))`)
+}
+
+func TestRecordResultHasExactlyItsFieldNames(t *testing.T) {
+	t.Parallel()
+	AssertCodesEvalToSameValue(t,
+		`(x: 1, y: 2)`,
+		`let Point = record(x, y); Point((x: 1, y: 2)).|x, y|`)
+	AssertCodeErrors(t, "names are not subset of lhs",
+		`let Point = record(x, y); Point((x: 1, y: 2)).|z|`)
+}
+
+func TestRecordRejectsTupleWithExtraAttrs(t *testing.T) {
+	t.Parallel()
+	AssertCodeErrors(t, "length of tuple (x: 1, y: 2, z: 3) longer than tuple pattern (x: x, y: y)",
+		`let Point = record(x, y); Point((x: 1, y: 2, z: 3))`)
+}
+
+func TestRecordRejectsTupleMissingAttrs(t *testing.T) {
+	t.Parallel()
+	AssertCodeErrors(t, "length of tuple (x: 1) shorter than tuple pattern (x: x, y: y)",
+		`let Point = record(x, y); Point((x: 1))`)
+}
@@ -39,3 +39,36 @@ func TestRecursionExpr(t *testing.T) {
 		`let rec 1 = 1; 2`,
 	)
 }
+
+// TestTailRecursion exercises self-recursive functions whose recursive call
+// is in tail position, which the compiler runs as an iterative loop instead
+// of growing the Go stack (see rel.TailRecursionExpr).
+func TestTailRecursion(t *testing.T) {
+	t.Parallel()
+
+	// A count-down over 1,000,000 iterations would overflow the Go stack if
+	// compiled as nested Eval calls.
+	AssertCodesEvalToSameValue(t,
+		`"done"`,
+		`let rec countdown = \n cond n {0: "done", n: countdown(n - 1)}; countdown(1000000)`,
+	)
+	AssertCodesEvalToSameValue(t,
+		`3`,
+		`let rec countdown = \n cond n {0: 3, n: countdown(n - 1)}; countdown(10)`,
+	)
+
+	// Non-tail recursion (the call is wrapped in `n * ...`) still works,
+	// falling back to the regular fixpoint recursion.
+	AssertCodesEvalToSameValue(t,
+		`120`,
+		`let rec fact = \n cond n {0: 1, n: n * fact(n - 1)}; fact(5)`,
+	)
+
+	// An unmatched cond, tail-recursive or not, evaluates to None, the same
+	// as a plain (non-recursive) cond with no matching branch and no
+	// default case.
+	AssertCodesEvalToSameValue(t,
+		`{}`,
+		`let rec countdown = \n cond n {0: 3, 2: countdown(n - 1)}; countdown(5)`,
+	)
+}
@@ -0,0 +1,27 @@
+package syntax
+
+import "testing"
+
+// TestReduceWhile checks `reduce init while cond do step`, an iterative
+// loop over an accumulator, distinct from the ARROW-style reduce/sum/max
+// operators which fold over a Set.
+func TestReduceWhile(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `10`, `reduce 0 while \acc acc < 10 do \acc acc + 1`)
+	AssertCodesEvalToSameValue(t, `0`, `reduce 0 while \acc acc < 0 do \acc acc + 1`)
+	AssertCodesEvalToSameValue(t,
+		`1024`,
+		`(reduce (n: 1, acc: 1) while \x x.n <= 10 do \x (n: x.n + 1, acc: x.acc * 2)).acc`,
+	)
+}
+
+// TestReduceWhileFixpointCap checks that a fixpoint loop that never
+// satisfies its cond errors out once it exceeds an explicit cap, rather
+// than looping forever.
+func TestReduceWhileFixpointCap(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `16`, `reduce 1 while \acc acc < 16 do \acc acc * 2 cap 10`)
+	AssertCodeErrors(t, "", `reduce 1 while \acc 1 < 2 do \acc acc cap 10`)
+}
@@ -0,0 +1,37 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegexReplaceGroupReferences(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t,
+		`"02/01/2024"`,
+		`replace("2024-01-02", "(\\d+)-(\\d+)-(\\d+)", "$3/$2/$1")`)
+}
+
+func TestRegexReplaceNoMatchPassesThrough(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"hello"`, `replace("hello", "xyz", "!")`)
+}
+
+func TestRegexReplaceConstantMalformedPatternIsCompileError(t *testing.T) {
+	t.Parallel()
+
+	_, err := Compile(NoPath, `replace("hello", "(", "!")`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "bad pattern")
+}
+
+func TestRegexReplaceDynamicMalformedPatternIsEvalError(t *testing.T) {
+	t.Parallel()
+
+	_, err := EvaluateExpr("", `let p = "("; replace("hello", p, "!")`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "bad pattern")
+}
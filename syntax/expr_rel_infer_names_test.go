@@ -0,0 +1,21 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelationLiteralInferredNames(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `{|x, y| (1, 2), (3, 4)}`, `let x = 1; let y = 2; {(x, y), (3, 4)}`)
+	AssertCodesEvalToSameValue(t,
+		`{|x, y| (1, 2)}`,
+		`let t = (x: 1, y: 2); {(t.x, t.y)}`,
+	)
+
+	_, err := mustCompileWith(t, ParseContext{}, `{(1, 2)}`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "relation literal missing |names|")
+}
@@ -0,0 +1,25 @@
+package syntax
+
+import "testing"
+
+// TestRepeatStringZeroIsEmpty checks that repeatStr(s, 0) is "".
+func TestRepeatStringZeroIsEmpty(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `""`, `repeatStr("ab", 0)`)
+}
+
+// TestRepeatStringConcatenatesNTimes checks that repeatStr(s, n) is s
+// concatenated with itself n times.
+func TestRepeatStringConcatenatesNTimes(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"ababab"`, `repeatStr("ab", 3)`)
+}
+
+// TestRepeatStringNegativeIsError checks that a negative n is an error.
+func TestRepeatStringNegativeIsError(t *testing.T) {
+	t.Parallel()
+
+	AssertCodeErrors(t, "repeatStr: n must be a non-negative whole number", `repeatStr("ab", -1)`)
+}
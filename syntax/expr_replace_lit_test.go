@@ -0,0 +1,29 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplaceLitReplacesAllOccurrences(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"bbb"`, `replaceLit("aaa", "a", "b")`)
+	AssertCodesEvalToSameValue(t, `"x.x.txt"`, `replaceLit("a.a.txt", "a", "x")`)
+}
+
+func TestReplaceLitWithCountLimitsReplacements(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"bba"`, `replaceLit("aaa", "a", "b", 2)`)
+	AssertCodesEvalToSameValue(t, `"aaa"`, `replaceLit("aaa", "a", "b", 0)`)
+}
+
+func TestReplaceLitEmptyOldIsCompileError(t *testing.T) {
+	t.Parallel()
+
+	_, err := Compile(NoPath, `replaceLit("hello", "", "x")`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "old must not be empty")
+}
@@ -0,0 +1,50 @@
+package syntax
+
+import (
+	"testing"
+)
+
+// Halfway values round away from zero, per math.Round.
+
+func TestRoundHalfwayValuesRoundAwayFromZero(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `3`, `round(2.5)`)
+	AssertCodesEvalToSameValue(t, `-3`, `round(-2.5)`)
+}
+
+func TestRoundNonHalfwayValues(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `2`, `round(2.4)`)
+	AssertCodesEvalToSameValue(t, `-2`, `round(-2.4)`)
+}
+
+func TestFloorPositiveAndNegative(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `2`, `floor(2.7)`)
+	AssertCodesEvalToSameValue(t, `-3`, `floor(-2.7)`)
+}
+
+func TestCeilPositiveAndNegative(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `3`, `ceil(2.1)`)
+	AssertCodesEvalToSameValue(t, `-2`, `ceil(-2.1)`)
+}
+
+func TestTruncatePositiveAndNegative(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `2`, `truncate(2.9)`)
+	AssertCodesEvalToSameValue(t, `-2`, `truncate(-2.9)`)
+}
+
+func TestRoundWithDecimalPlaces(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `3.14`, `round(3.14159, 2)`)
+	AssertCodesEvalToSameValue(t, `3.15`, `round(3.14559, 2)`)
+	AssertCodesEvalToSameValue(t, `-3.15`, `round(-3.14559, 2)`)
+}
@@ -30,3 +30,36 @@ func TestSafeTail(t *testing.T) {
 	AssertCodeErrors(t, `Missing attr "c" (available: |b|)`, `(a: (b: 1)).a?.c:42          `)
 	AssertCodeErrors(t, `Call: no return values for input c from set {b: 1}`, `{"a": {"b": 1}}("a")?("c"):42`)
 }
+
+// TestSafeTailNoneGuard checks that `a?.b??:fallback` (doubled "?" before
+// the colon) also falls back when an intermediate value is None, e.g. an
+// attr that exists but holds {}, unlike plain `a?.b?:fallback` which only
+// guards against outright failures like a missing attr.
+func TestSafeTailNoneGuard(t *testing.T) {
+	t.Parallel()
+
+	// Under the existing mode, an attr holding {} proceeds, yielding {}.
+	AssertCodesEvalToSameValue(t, `{}`, `(a: {}).a?:42`)
+	AssertCodesEvalToSameValue(t, `{}`, `(a: (b: {})).a?.b?:42`)
+
+	// Under the new mode, an attr holding {} falls back instead.
+	AssertCodesEvalToSameValue(t, `42`, `(a: {}).a??:42`)
+	AssertCodesEvalToSameValue(t, `42`, `(a: (b: {})).a?.b??:42`)
+
+	// Both modes still guard against outright failures like a missing attr.
+	AssertCodesEvalToSameValue(t, `42`, `(a: 1).b??:42`)
+
+	// Both modes still let a present, non-None value through.
+	AssertCodesEvalToSameValue(t, `1`, `(a: 1).a??:42`)
+}
+
+// TestSafeTailFallbackNotEvaluatedOnSuccess verifies that the fallback
+// expression of a safe-tail chain is only evaluated when a tail actually
+// fails, never on the success path, even when the fallback would itself
+// error if evaluated.
+func TestSafeTailFallbackNotEvaluatedOnSuccess(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `1`, `(a: 1).a?:(1(2))`)
+	AssertCodeErrors(t, "call lhs must be a function", `(a: 1).b?:(1(2))`)
+}
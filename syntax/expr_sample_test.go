@@ -0,0 +1,26 @@
+package syntax
+
+import "testing"
+
+func TestSample(t *testing.T) {
+	t.Parallel()
+	AssertCodesEvalToSameValue(t, `{3, 4, 5}`, `{1, 2, 3, 4, 5} sample(3, 42)`)
+}
+
+func TestSampleIsReproducibleWithFixedSeed(t *testing.T) {
+	t.Parallel()
+	AssertCodesEvalToSameValue(t,
+		`{1, 2, 3, 4, 5} sample(3, 42)`,
+		`{1, 2, 3, 4, 5} sample(3, 42)`)
+}
+
+func TestSampleDifferentSeedsCanDiffer(t *testing.T) {
+	t.Parallel()
+	AssertCodesEvalToSameValue(t, `{1, 3}`, `{1, 2, 3, 4, 5} sample(2, 1)`)
+	AssertCodesEvalToSameValue(t, `{3, 4}`, `{1, 2, 3, 4, 5} sample(2, 2)`)
+}
+
+func TestSampleBeyondCollectionSizeReturnsAll(t *testing.T) {
+	t.Parallel()
+	AssertCodesEvalToSameValue(t, `{1, 2, 3}`, `{1, 2, 3} sample(10, 1)`)
+}
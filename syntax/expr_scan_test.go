@@ -0,0 +1,29 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanRunningSum(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t,
+		`[0, 1, 3, 6]`,
+		`[1, 2, 3] scan(0, \acc \x acc + x)`)
+}
+
+func TestScanEmptyInputReturnsInit(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `[0]`, `[] scan(0, \acc \x acc + x)`)
+}
+
+func TestScanOnNonEmptyUnorderedSetErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := EvaluateExpr("", `{1, 2, 3} scan(0, \acc \x acc + x)`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "scan lhs must be an ordered Array")
+}
@@ -25,3 +25,24 @@ func TestExprSetWithout(t *testing.T) {
 	AssertCodesEvalToSameValue(t, `{}`, `{1} without 1`)
 	AssertCodesEvalToSameValue(t, `{{}}`, `{1, {}} without 1`)
 }
+
+// TestExprArrayUpdate checks `arr with [i] = v`, which replaces the element
+// at index i with v, counting negative indices from the end.
+func TestExprArrayUpdate(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `[9, 2, 3]`, `[1, 2, 3] with [0] = 9`)
+	AssertCodesEvalToSameValue(t, `[1, 2, 9]`, `[1, 2, 3] with [2] = 9`)
+
+	// Negative indices count from the end.
+	AssertCodesEvalToSameValue(t, `[1, 2, 9]`, `[1, 2, 3] with [-1] = 9`)
+	AssertCodesEvalToSameValue(t, `[9, 2, 3]`, `[1, 2, 3] with [-3] = 9`)
+
+	// Out of range, in either direction, is an eval-time error.
+	AssertCodeErrors(t, "", `[1, 2, 3] with [3] = 9`)
+	AssertCodeErrors(t, "", `[1, 2, 3] with [-4] = 9`)
+	AssertCodeErrors(t, "", `[] with [0] = 9`)
+
+	// Chains with the plain set with/without operators.
+	AssertCodesEvalToSameValue(t, `[9, 2]`, `[1, 2] with [0] = 9 without 1`)
+}
@@ -0,0 +1,39 @@
+package syntax
+
+import "testing"
+
+// TestBytesIndexAndSlice checks `bs(i)`, which returns the byte at index i
+// as a Number (0-255), and `bs(lo:hi)`/`bs(lo:hi:step)`, which return a
+// sub-range of bytes, analogous to the existing Array and String slicing.
+func TestBytesIndexAndSlice(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `104`, `<<104, 105, 106>>(0)`)
+	AssertCodesEvalToSameValue(t, `106`, `<<104, 105, 106>>(2)`)
+	AssertCodeErrors(t,
+		`Call: no return values for input 3 from set hij`,
+		`<<104, 105, 106>>(3)`,
+	)
+
+	AssertCodesEvalToSameValue(t, `<<105, 106>>`, `<<104, 105, 106>>(1:3)`)
+	AssertCodesEvalToSameValue(t, `<<104, 105, 106>>`, `<<104, 105, 106>>(0:)`)
+	AssertCodesEvalToSameValue(t, `<<104>>`, `<<104, 105, 106>>(:1)`)
+	AssertCodesEvalToSameValue(t, `<<104, 106>>`, `<<104, 105, 106>>(0:3:2)`)
+
+	// Negative indices count from the end; out-of-range bounds clamp rather
+	// than error.
+	AssertCodesEvalToSameValue(t, `<<106>>`, `<<104, 105, 106>>(-1:)`)
+	AssertCodesEvalToSameValue(t, `<<104, 105, 106>>`, `<<104, 105, 106>>(0:100)`)
+}
+
+// TestSliceExpr checks general `(lo:hi:step)` slicing of Arrays and
+// Strings, sharing the same compile path as bytes slicing.
+func TestSliceExpr(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `[2, 3, 5]`, `[1, 1, 2, 3, 5, 8](2:5)`)
+	AssertCodesEvalToSameValue(t, `[2, 4]`, `[1, 2, 3, 4, 5, 6](1:5:2)`)
+	AssertCodesEvalToSameValue(t, `"ell"`, `"hello"(1:4)`)
+
+	AssertCodeErrors(t, "", `[1, 2, 3](1:2:0)`)
+}
@@ -0,0 +1,22 @@
+package syntax
+
+import (
+	"testing"
+)
+
+func TestInsertSortedWithoutKey(t *testing.T) {
+	t.Parallel()
+	AssertCodesEvalToSameValue(t, `[1, 2, 3, 4]`, `insertSorted([2, 3, 4], 1)`)
+	AssertCodesEvalToSameValue(t, `[1, 2, 3, 4]`, `insertSorted([1, 3, 4], 2)`)
+	AssertCodesEvalToSameValue(t, `[1, 2, 3, 4]`, `insertSorted([1, 2, 3], 4)`)
+}
+
+func TestInsertSortedWithKey(t *testing.T) {
+	t.Parallel()
+	AssertCodesEvalToSameValue(t, `[(n: 1), (n: 3), (n: 5)]`,
+		`insertSorted([(n: 3), (n: 5)], (n: 1), \x x.n)`)
+	AssertCodesEvalToSameValue(t, `[(n: 1), (n: 3), (n: 5)]`,
+		`insertSorted([(n: 1), (n: 5)], (n: 3), \x x.n)`)
+	AssertCodesEvalToSameValue(t, `[(n: 1), (n: 3), (n: 5)]`,
+		`insertSorted([(n: 1), (n: 3)], (n: 5), \x x.n)`)
+}
@@ -0,0 +1,31 @@
+package syntax
+
+import (
+	"testing"
+)
+
+func TestSplitAtInteriorIndex(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `(prefix: [1, 2], suffix: [3, 4, 5])`, `splitAt([1, 2, 3, 4, 5], 2)`)
+}
+
+func TestSplitAtBoundaryIndices(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `(prefix: [], suffix: [1, 2, 3])`, `splitAt([1, 2, 3], 0)`)
+	AssertCodesEvalToSameValue(t, `(prefix: [1, 2, 3], suffix: [])`, `splitAt([1, 2, 3], 3)`)
+}
+
+func TestSplitAtOutOfRangeIndexClamps(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `(prefix: [1, 2, 3], suffix: [])`, `splitAt([1, 2, 3], 10)`)
+}
+
+func TestSplitAtNegativeIndexCountsFromEnd(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `(prefix: [1, 2, 3], suffix: [4, 5])`, `splitAt([1, 2, 3, 4, 5], -2)`)
+	AssertCodesEvalToSameValue(t, `(prefix: [], suffix: [1, 2, 3])`, `splitAt([1, 2, 3], -10)`)
+}
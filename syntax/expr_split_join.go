@@ -0,0 +1,68 @@
+package syntax
+
+import (
+	"fmt"
+
+	"github.com/arr-ai/arrai/rel"
+	"github.com/arr-ai/wbnf/parser"
+)
+
+// SplitExpr is `s split sep`, sugar for //seq.split(sep, s): an array of the
+// substrings of s delimited by sep. An empty sep splits s into its runes.
+type SplitExpr struct {
+	rel.ExprScanner
+	s, sep rel.Expr
+}
+
+// NewSplitExpr returns a new SplitExpr.
+func NewSplitExpr(scanner parser.Scanner, s, sep rel.Expr) rel.Expr {
+	return &SplitExpr{rel.ExprScanner{Src: scanner}, s, sep}
+}
+
+// String returns a string representation of the expression.
+func (e *SplitExpr) String() string {
+	return fmt.Sprintf("(%s split %s)", e.s, e.sep)
+}
+
+// Eval evaluates the split.
+func (e *SplitExpr) Eval(local rel.Scope) (rel.Value, error) {
+	s, err := e.s.Eval(local)
+	if err != nil {
+		return nil, rel.WrapContext(err, e, local)
+	}
+	sep, err := e.sep.Eval(local)
+	if err != nil {
+		return nil, rel.WrapContext(err, e, local)
+	}
+	return stdSeqSplit(sep, s)
+}
+
+// JoinExpr is `arr join sep`, sugar for //seq.join(sep, arr): the
+// concatenation of arr's elements with sep between each pair.
+type JoinExpr struct {
+	rel.ExprScanner
+	arr, sep rel.Expr
+}
+
+// NewJoinExpr returns a new JoinExpr.
+func NewJoinExpr(scanner parser.Scanner, arr, sep rel.Expr) rel.Expr {
+	return &JoinExpr{rel.ExprScanner{Src: scanner}, arr, sep}
+}
+
+// String returns a string representation of the expression.
+func (e *JoinExpr) String() string {
+	return fmt.Sprintf("(%s join %s)", e.arr, e.sep)
+}
+
+// Eval evaluates the join.
+func (e *JoinExpr) Eval(local rel.Scope) (rel.Value, error) {
+	arr, err := e.arr.Eval(local)
+	if err != nil {
+		return nil, rel.WrapContext(err, e, local)
+	}
+	sep, err := e.sep.Eval(local)
+	if err != nil {
+		return nil, rel.WrapContext(err, e, local)
+	}
+	return stdSeqJoin(sep, arr)
+}
@@ -0,0 +1,25 @@
+package syntax
+
+import "testing"
+
+func TestSplitOperator(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `["this", "is", "a", "test"]`, `"this is a test" split " "`)
+	AssertCodesEvalToSameValue(t, `["a", "bb", "ccc"]`, `"a::bb::ccc" split "::"`)
+	AssertCodesEvalToSameValue(t,
+		`["t", "h", "i", "s"]`,
+		`"this" split ""`)
+}
+
+func TestJoinOperator(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"a,bb,ccc"`, `["a", "bb", "ccc"] join ","`)
+}
+
+func TestSplitJoinRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"a::bb::ccc"`, `("a::bb::ccc" split "::") join "::"`)
+}
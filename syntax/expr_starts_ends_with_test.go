@@ -0,0 +1,33 @@
+package syntax
+
+import (
+	"testing"
+)
+
+func TestStartsWithMatchingAndNonMatchingPrefix(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `true`, `"hello world" startsWith "hello"`)
+	AssertCodesEvalToSameValue(t, `false`, `"hello world" startsWith "world"`)
+}
+
+func TestEndsWithMatchingAndNonMatchingSuffix(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `true`, `"hello world" endsWith "world"`)
+	AssertCodesEvalToSameValue(t, `false`, `"hello world" endsWith "hello"`)
+}
+
+func TestStartsWithEndsWithEmptyAffixIsTrue(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `true`, `"hello" startsWith ""`)
+	AssertCodesEvalToSameValue(t, `true`, `"hello" endsWith ""`)
+}
+
+func TestStartsWithEndsWithNonStringIsError(t *testing.T) {
+	t.Parallel()
+
+	AssertCodeErrors(t, `startsWith: argument must be a String, not rel.Number`, `1 startsWith "1"`)
+	AssertCodeErrors(t, `endsWith: argument must be a String, not rel.Number`, `"hello" endsWith 1`)
+}
@@ -0,0 +1,43 @@
+package syntax
+
+import "testing"
+
+// TestStringWidthASCIIIsRuneCount checks that ASCII characters each
+// contribute one column, matching the rune count.
+func TestStringWidthASCIIIsRuneCount(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `3`, `displayWidth("abc")`)
+}
+
+// TestStringWidthCJKCountsTwoPerRune checks that CJK ideographs each
+// contribute two columns.
+func TestStringWidthCJKCountsTwoPerRune(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `6`, `displayWidth("日本語")`)
+}
+
+// TestStringWidthMixedASCIIAndCJK checks that widths add up correctly
+// across a mix of narrow and wide runes.
+func TestStringWidthMixedASCIIAndCJK(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `4`, `displayWidth("a日b")`)
+}
+
+// TestStringWidthCombiningMarkContributesZero checks that a combining mark
+// following a base rune contributes no extra columns, unlike rune count.
+func TestStringWidthCombiningMarkContributesZero(t *testing.T) {
+	t.Parallel()
+
+	// "e" followed by U+0301 COMBINING ACUTE ACCENT: two runes, one column.
+	AssertCodesEvalToSameValue(t, `1`, "displayWidth(\"é\")")
+}
+
+// TestStringWidthEmptyIsZero checks that the empty string has width 0.
+func TestStringWidthEmptyIsZero(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `0`, `displayWidth("")`)
+}
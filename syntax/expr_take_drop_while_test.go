@@ -0,0 +1,35 @@
+package syntax
+
+import (
+	"testing"
+)
+
+func TestTakeWhileStopsAtFirstFailingElement(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `[1, 2, 3]`, `[1, 2, 3, 10, 4, 5] takeWhile \x x < 5`)
+}
+
+func TestDropWhileStartsAtFirstFailingElement(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `[10, 4, 5]`, `[1, 2, 3, 10, 4, 5] dropWhile \x x < 5`)
+}
+
+func TestTakeWhileAllMatchingReturnsWholeArray(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `[1, 2, 3]`, `[1, 2, 3] takeWhile \x x < 5`)
+}
+
+func TestDropWhileNoneMatchingReturnsWholeArray(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `[1, 2, 3]`, `[1, 2, 3] dropWhile \x x > 5`)
+}
+
+func TestTakeWhileNonArrayLhsIsError(t *testing.T) {
+	t.Parallel()
+
+	AssertCodeErrors(t, `'takeWhile' lhs must be an ordered Array, not rel.GenericSet`, `{1, 2} takeWhile \x x < 5`)
+}
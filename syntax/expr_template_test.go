@@ -0,0 +1,24 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateRendersPlaceholdersFromTuple(t *testing.T) {
+	t.Parallel()
+	AssertCodesEvalToSameValue(t,
+		`"Hello, Ada! You are 30."`,
+		`render("Hello, {name}! You are {age}.", (name: "Ada", age: 30))`)
+}
+
+func TestTemplateMissingPlaceholderIsCatchable(t *testing.T) {
+	t.Parallel()
+	_, err := EvaluateExpr("", `render("Hello, {name}!", (other: 1))`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `"name"`)
+
+	AssertCodesEvalToSameValue(t, `"caught"`,
+		`try render("Hello, {name}!", (other: 1)) catch missingAttr "caught"`)
+}
@@ -0,0 +1,36 @@
+package syntax
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arr-ai/arrai/rel"
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeNowUsesInjectedClock(t *testing.T) {
+	t.Parallel()
+
+	fixed := time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+	pc := ParseContext{Clock: func() time.Time { return fixed }}
+
+	ast, err := pc.Parse(parser.NewScanner(`now()`))
+	require.NoError(t, err)
+	value, err := pc.CompileExpr(ast).Eval(rel.Scope{})
+	require.NoError(t, err)
+
+	assert.Equal(t, rel.NewNumber(float64(fixed.Unix())), value)
+}
+
+func TestTimeNowDisallowedByCapability(t *testing.T) {
+	t.Parallel()
+
+	_, err := mustCompileWith(t, ParseContext{DisallowAmbientTime: true}, `now()`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ambient time not permitted")
+
+	_, err = mustCompileWith(t, ParseContext{}, `now()`)
+	assert.NoError(t, err)
+}
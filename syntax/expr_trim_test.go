@@ -0,0 +1,38 @@
+package syntax
+
+import (
+	"testing"
+)
+
+func TestTrimWhitespace(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"hi"`, `trim("  hi  ", " ")`)
+	AssertCodesEvalToSameValue(t, `"hi  "`, `trimLeft("  hi  ", " ")`)
+	AssertCodesEvalToSameValue(t, `"  hi"`, `trimRight("  hi  ", " ")`)
+}
+
+func TestTrimCustomCutSet(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"hi"`, `trim("xyhixy", "xy")`)
+	AssertCodesEvalToSameValue(t, `"hixy"`, `trimLeft("xyhixy", "xy")`)
+	AssertCodesEvalToSameValue(t, `"xyhi"`, `trimRight("xyhixy", "xy")`)
+}
+
+func TestTrimPrefixAndSuffix(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `".go"`, `trimPrefix("hello.go", "hello")`)
+	AssertCodesEvalToSameValue(t, `"hello"`, `trimSuffix("hello.go", ".go")`)
+	AssertCodesEvalToSameValue(t, `"hello.go"`, `trimPrefix("hello.go", "world")`)
+	AssertCodesEvalToSameValue(t, `"hello.go"`, `trimSuffix("hello.go", ".txt")`)
+}
+
+func TestTrimWithEmptyCutSetIsNoOp(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"  hi  "`, `trim("  hi  ", "")`)
+	AssertCodesEvalToSameValue(t, `"hello.go"`, `trimPrefix("hello.go", "")`)
+	AssertCodesEvalToSameValue(t, `"hello.go"`, `trimSuffix("hello.go", "")`)
+}
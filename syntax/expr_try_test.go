@@ -0,0 +1,20 @@
+package syntax
+
+import "testing"
+
+// TestTryCatch checks `try body catch kind handler`, which substitutes
+// handler for body only when body fails with the named error kind,
+// letting any other error propagate unchanged.
+func TestTryCatch(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `42`, `try (a: 1).c catch missingAttr 42`)
+	AssertCodesEvalToSameValue(t, `1`, `try (a: 1).a catch missingAttr 1`)
+	AssertCodesEvalToSameValue(t, `42`, `try {"a": 1}("b") catch noReturn 42`)
+
+	// A different error kind is not caught; it propagates.
+	AssertCodeErrors(t,
+		"call lhs must be a function, not rel.Number",
+		`try (1)(2) catch missingAttr 42`,
+	)
+}
@@ -0,0 +1,24 @@
+package syntax
+
+import "testing"
+
+// TestTupleProjectRenameExpr tests the `t.|new::old, ...|` attribute
+// renaming projection.
+func TestTupleProjectRenameExpr(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `(x: 1)`, `(a: 1, b: 2, c: 3).|x::a|`)
+	AssertCodesEvalToSameValue(t, `(x: 1, y: 2)`, `(a: 1, b: 2, c: 3).|x::a, y::b|`)
+	AssertCodesEvalToSameValue(t, `(a: 1, y: 2)`, `(a: 1, b: 2, c: 3).|a::a, y::b|`)
+
+	AssertCodeErrors(t, `lhs does not evaluate to tuple: {1: 1}`, `{1: 1}.|x::a|`)
+	AssertCodeErrors(t, `no such attr: d`, `(a: 1, b: 2, c: 3).|x::d|`)
+
+	_, err := mustCompileWith(t, ParseContext{}, `(a: 1, b: 2, c: 3).|x::a, x::b|`)
+	if err == nil {
+		t.Fatal("expected a compile error for a renamed attr name collision")
+	}
+	if got, want := err.Error(), `rename: duplicate attr name: "x"`; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
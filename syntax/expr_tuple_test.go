@@ -29,6 +29,15 @@ func TestTupleGet(t *testing.T) {
 	AssertCodesEvalToSameValue(t, `42`, `(a: 1, '': 42).""`)
 }
 
+func TestTupleGetAmpName(t *testing.T) {
+	t.Parallel()
+	// `.&name` is accepted as an alias for plain `.name` access; arrai's
+	// values are immutable, so there is no reference-vs-copy distinction
+	// to compile differently.
+	AssertCodesEvalToSameValue(t, `42`, `(a: 1, b: 42).&b`)
+	AssertCodesEvalToSameValue(t, `(a: 1, b: 42).b`, `(a: 1, b: 42).&b`)
+}
+
 func TestTupleCallGet(t *testing.T) {
 	t.Parallel()
 	AssertCodesEvalToSameValue(t, `2`, `(a: \x (b: x)).a(2).b`)
@@ -43,6 +52,18 @@ func TestTupleLiteral(t *testing.T) {
 	AssertCodesEvalToSameValue(t, `(x: 1, y: 2)`, `(x: 1, y: 2) -> (:.x, :.y)`)
 }
 
+// TestTupleComputedAttr tests the `(::expr: value)` computed attribute name
+// form, where the attr name is a runtime string rather than fixed at
+// compile time.
+func TestTupleComputedAttr(t *testing.T) {
+	t.Parallel()
+	AssertCodesEvalToSameValue(t, `(x: 1)`, `(::"x": 1)`)
+	AssertCodesEvalToSameValue(t, `(x: 1, y: 2)`, `let k = "y"; (x: 1, ::k: 2)`)
+	AssertCodesEvalToSameValue(t, `(foo: 42)`, `let k = "f" ++ "oo"; (::k: 42)`)
+
+	AssertCodeErrors(t, `computed attr name must be a string, not rel.Number(1)`, `(::1: 42)`)
+}
+
 func TestTupleRec(t *testing.T) {
 	t.Parallel()
 	AssertCodesEvalToSameValue(t,
@@ -0,0 +1,34 @@
+package syntax
+
+import "testing"
+
+// TestUnionDefConstructsTaggedTuples checks that `|Union| Ctor(p, ...); body`
+// binds each constructor to a curried function building a tuple tagged with
+// its own name, with the rest of its params as ordinary attrs.
+func TestUnionDefConstructsTaggedTuples(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `(tag: "Circle", r: 3)`,
+		`|Shape| Circle(r) | Square(s); Circle(3)`)
+	AssertCodesEvalToSameValue(t, `(tag: "Point", x: 1, y: 2)`,
+		`|Shape| Point(x, y); Point(1)(2)`)
+}
+
+// TestUnionDefPatternMatchesOnTag checks that cond can switch on a tagged
+// tuple's tag attr to tell two constructors' results apart and destructure
+// each variant's own params, using plain tuple patterns.
+func TestUnionDefPatternMatchesOnTag(t *testing.T) {
+	t.Parallel()
+
+	area := func(shape string) string {
+		return `|Shape| Circle(r) | Square(s);
+			let shape = ` + shape + `;
+			cond shape {
+				(tag: "Circle", r: r): r * r,
+				(tag: "Square", s: s): s * s,
+			}`
+	}
+
+	AssertCodesEvalToSameValue(t, `9`, area(`Circle(3)`))
+	AssertCodesEvalToSameValue(t, `25`, area(`Square(5)`))
+}
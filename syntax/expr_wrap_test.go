@@ -0,0 +1,36 @@
+package syntax
+
+import "testing"
+
+// TestWordWrapPacksWordsToWidth checks that words are greedily packed onto
+// lines no wider than the given width.
+func TestWordWrapPacksWordsToWidth(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"the quick\nbrown fox\njumps over"`, `wordWrap("the quick brown fox jumps over", 10)`)
+}
+
+// TestWordWrapPreservesExistingNewlinesAsParagraphs checks that newlines
+// already in s are kept as paragraph breaks, with each paragraph wrapped
+// independently.
+func TestWordWrapPreservesExistingNewlinesAsParagraphs(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(
+		t,
+		`"para one\nhere\npara two\nhere"`,
+		`wordWrap("para one here\npara two here", 8)`,
+	)
+}
+
+// TestWordWrapDoesNotBreakOverLongWord checks that a word longer than width
+// is placed alone on its own line rather than being broken.
+func TestWordWrapDoesNotBreakOverLongWord(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(
+		t,
+		`"supercalifragilisticexpialidocious\nis long"`,
+		`wordWrap("supercalifragilisticexpialidocious is long", 10)`,
+	)
+}
@@ -0,0 +1,50 @@
+package syntax
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/arr-ai/arrai/rel"
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteWritesValueToInjectedSink(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	pc := ParseContext{Sinks: map[string]io.Writer{"buf": &buf}}
+
+	ast, err := pc.Parse(parser.NewScanner(`write(buf, "hello")`))
+	require.NoError(t, err)
+	value, err := pc.CompileExpr(ast).Eval(rel.Scope{})
+	require.NoError(t, err)
+
+	assert.Equal(t, rel.NewString([]rune("hello")), value)
+	assert.Equal(t, "hello", buf.String())
+}
+
+func TestWriteReturnsValueForChaining(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	pc := ParseContext{Sinks: map[string]io.Writer{"buf": &buf}}
+
+	ast, err := pc.Parse(parser.NewScanner(`write(buf, 1) + write(buf, 2)`))
+	require.NoError(t, err)
+	value, err := pc.CompileExpr(ast).Eval(rel.Scope{})
+	require.NoError(t, err)
+
+	assert.Equal(t, rel.NewNumber(3), value)
+	assert.Equal(t, "12", buf.String())
+}
+
+func TestWriteUnknownSinkIsCompileError(t *testing.T) {
+	t.Parallel()
+
+	_, err := mustCompileWith(t, ParseContext{}, `write(notasink, "hello")`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown sink: "notasink"`)
+}
@@ -0,0 +1,35 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestXMLDecodeAttributesAndNestedElements(t *testing.T) {
+	t.Parallel()
+	AssertCodesEvalToSameValue(t,
+		`(tag: "root", attrs: (a: "1"), children: [
+			(tag: "child", attrs: (), children: ["text"])
+		])`,
+		`xmlDecode("<root a=\"1\"><child>text</child></root>")`)
+}
+
+func TestXMLDecodePreservesNamespaceInTagNames(t *testing.T) {
+	t.Parallel()
+	AssertCodesEvalToSameValue(t,
+		`(tag: "urn:x:root", attrs: ("xmlns:ns": "urn:x"), children: [
+			(tag: "urn:x:child", attrs: (), children: [])
+		])`,
+		`xmlDecode('<ns:root xmlns:ns="urn:x"><ns:child/></ns:root>')`)
+}
+
+func TestXMLDecodeMalformedInputIsCatchable(t *testing.T) {
+	t.Parallel()
+	_, err := EvaluateExpr("", `xmlDecode("<root><unclosed></root>")`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "line 1")
+
+	AssertCodesEvalToSameValue(t, `"caught"`,
+		`try xmlDecode("<root><unclosed></root>") catch xmlDecode "caught"`)
+}
@@ -0,0 +1,567 @@
+package syntax
+
+import (
+	"sort"
+
+	"github.com/arr-ai/wbnf/ast"
+	"github.com/arr-ai/wbnf/parser"
+
+	"github.com/arr-ai/arrai/rel"
+)
+
+// FreeIdents returns the names referenced but not bound within expr, e.g. to
+// determine which std packages and externals a snippet needs. It re-parses
+// expr's original source (every rel.Expr retains its Source()) and walks the
+// resulting AST the same way CompileExpr does, tracking names bound by
+// lambdas, lets and patterns along the way so shadowed names are excluded.
+// It returns nil if expr's source can't be re-parsed.
+func FreeIdents(expr rel.Expr) []string {
+	b, err := (ParseContext{}).Parse(parser.NewScanner(expr.Source().String()))
+	if err != nil {
+		return nil
+	}
+	free := map[string]struct{}{}
+	(ParseContext{}).freeIdentsExpr(b, map[string]struct{}{}, free)
+	if len(free) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(free))
+	for name := range free {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func cloneBound(bound map[string]struct{}, names ...string) map[string]struct{} {
+	clone := make(map[string]struct{}, len(bound)+len(names))
+	for name := range bound {
+		clone[name] = struct{}{}
+	}
+	for _, name := range names {
+		clone[name] = struct{}{}
+	}
+	return clone
+}
+
+func identName(c ast.Children) string {
+	return c.(ast.One).Node.One("").(ast.Leaf).Scanner().String()
+}
+
+// freeIdentsExpr walks b the same way CompileExpr dispatches on it, recording
+// into free any identifier reference not present in bound.
+func (pc ParseContext) freeIdentsExpr(b ast.Branch, bound, free map[string]struct{}) {
+	name, c := which(b,
+		"amp", "arrow", "let", "letm", "uniondef", "importAs", "unop", "withop", "binop", "compare", "matches",
+		"rbinop", "if", "get", "tail_op", "postfix", "touch", "convert", "get", "rel", "set", "dict", "array",
+		"bytes", "embed", "op", "fn", "pkg", "tuple", "xstr", "IDENT", "STR", "NUM", "CHAR",
+		"cond", "iflet", "reduceWhile", "try", exprTag,
+	)
+	if c == nil {
+		return
+	}
+	switch name {
+	case "amp", "arrow":
+		pc.freeIdentsArrow(b, bound, free)
+	case "let":
+		pc.freeIdentsLet(c, bound, free)
+	case "letm":
+		pc.freeIdentsLetMulti(c, bound, free)
+	case "uniondef":
+		pc.freeIdentsUnionDef(c, bound, free)
+	case "importAs":
+		pc.freeIdentsImportAs(c, bound, free)
+	case "unop", "postfix", "touch", "convert":
+		pc.freeIdentsExpr(b.One(exprTag).(ast.Branch), bound, free)
+	case "withop":
+		pc.freeIdentsWithOp(b, c, bound, free)
+	case "binop", "compare":
+		for _, e := range b.Many(exprTag) {
+			pc.freeIdentsExpr(e.(ast.Branch), bound, free)
+		}
+	case "matches":
+		pc.freeIdentsMatches(b, bound, free)
+	case "rbinop":
+		for _, e := range b[exprTag].(ast.Many) {
+			pc.freeIdentsExpr(e.(ast.Branch), bound, free)
+		}
+	case "if":
+		pc.freeIdentsIf(b, c, bound, free)
+	case "cond":
+		pc.freeIdentsCond(c, bound, free)
+	case "iflet":
+		pc.freeIdentsIfLet(c, bound, free)
+	case "reduceWhile":
+		pc.freeIdentsReduceWhile(c, bound, free)
+	case "try":
+		pc.freeIdentsTry(c, bound, free)
+	case "get", "tail_op":
+		pc.freeIdentsCallGet(b, bound, free)
+	case "rel":
+		pc.freeIdentsRelation(c, bound, free)
+	case "set":
+		pc.freeIdentsSet(c, bound, free)
+	case "dict":
+		pc.freeIdentsDict(c, bound, free)
+	case "array":
+		pc.freeIdentsSparseItems(c, bound, free)
+	case "bytes":
+		if items := c.(ast.One).Node.(ast.Branch)["item"]; items != nil {
+			for _, e := range items.(ast.Many) {
+				pc.freeIdentsExpr(e.(ast.Branch), bound, free)
+			}
+		}
+	case "embed", "pkg", "op":
+		// Macro expansions and package imports contribute no local free
+		// identifiers; in particular, a "pkg" node must never be compiled
+		// here, since that would trigger real import resolution as a side
+		// effect of this purely static analysis.
+	case "fn":
+		pc.freeIdentsFunction(b, bound, free)
+	case "tuple":
+		pc.freeIdentsTuple(c, bound, free)
+	case "IDENT":
+		ident := identName(c)
+		if ident == "true" || ident == "false" {
+			return
+		}
+		if _, isBound := bound[ident]; !isBound {
+			free[ident] = struct{}{}
+		}
+	case "STR", "NUM", "CHAR":
+		// Literals; no identifiers.
+	case "xstr":
+		pc.freeIdentsXstr(c, bound, free)
+	case exprTag:
+		switch c := c.(type) {
+		case ast.One:
+			pc.freeIdentsExpr(c.Node.(ast.Branch), bound, free)
+		case ast.Many:
+			if len(c) == 1 {
+				pc.freeIdentsExpr(c[0].(ast.Branch), bound, free)
+			}
+		}
+	}
+}
+
+func (pc ParseContext) freeIdentsArrow(b ast.Branch, bound, free map[string]struct{}) {
+	// The "-" placed in scope by the `&expr` (amp) form is introduced by
+	// wrapping the already-compiled inner expr in a Function, so it's not
+	// visible within the inner expr itself; recurse with bound unchanged.
+	pc.freeIdentsExpr(b[exprTag].(ast.One).Node.(ast.Branch), bound, free)
+	if arrows, has := b["arrow"]; has {
+		for _, arrow := range arrows.(ast.Many) {
+			branch := arrow.(ast.Branch)
+			part, _ := which(branch, "nest", "unnest", "ARROW", "binding", "FILTER")
+			switch part {
+			case "nest", "unnest":
+				// Neither form references an identifier of its own.
+			case "ARROW":
+				pc.freeIdentsExpr(arrow.(ast.Branch)[exprTag].(ast.One).Node.(ast.Branch), bound, free)
+			case "binding":
+				rhsBound := bound
+				if pattern := arrow.One("pattern"); pattern != nil {
+					names := pc.patternBoundAndFree(pattern.(ast.Branch), bound, free)
+					rhsBound = cloneBound(bound, names...)
+				}
+				pc.freeIdentsExpr(arrow.(ast.Branch)[exprTag].(ast.One).Node.(ast.Branch), rhsBound, free)
+			case "FILTER":
+				// `s filter cond { ... }` nests a cond-shaped structure on
+				// this same branch; re-dispatch through it.
+				pc.freeIdentsExpr(branch, bound, free)
+			}
+		}
+	}
+}
+
+// let PATTERN = EXPR1; EXPR2
+func (pc ParseContext) freeIdentsLet(c ast.Children, bound, free map[string]struct{}) {
+	node := c.(ast.One).Node.(ast.Branch)
+	exprs := node.Many(exprTag)
+
+	names := pc.patternBoundAndFree(node, bound, free)
+	bodyBound := cloneBound(bound, names...)
+
+	valueBound := bound
+	if node.One("rec") != nil {
+		valueBound = bodyBound
+	}
+	pc.freeIdentsExpr(exprs[0].(ast.Branch), valueBound, free)
+	pc.freeIdentsExpr(exprs[1].(ast.Branch), bodyBound, free)
+}
+
+func (pc ParseContext) freeIdentsLetMulti(c ast.Children, bound, free map[string]struct{}) {
+	node := c.(ast.One).Node.(ast.Branch)
+	cur := bound
+	for _, bindingNode := range node.Many("bindings") {
+		branch := bindingNode.(ast.Branch)
+		pc.freeIdentsExpr(branch.One(exprTag).(ast.Branch), cur, free)
+		names := pc.patternBoundAndFree(branch, cur, free)
+		cur = cloneBound(cur, names...)
+	}
+	pc.freeIdentsExpr(node.One(exprTag).(ast.Branch), cur, free)
+}
+
+// |Union| Ctor1(p1, ...) | Ctor2(p1, ...); body binds each CtorN, per
+// compileUnionDef; ctor params are local to each ctor's own curried
+// function and contribute no free identifiers of their own.
+func (pc ParseContext) freeIdentsUnionDef(c ast.Children, bound, free map[string]struct{}) {
+	node := c.(ast.One).Node.(ast.Branch)
+	ctors := node.Many("ctor")
+	names := make([]string, len(ctors))
+	for i, ctor := range ctors {
+		names[i] = ctor.(ast.Branch).One("ctorName").One("IDENT").One("").(ast.Leaf).Scanner().String()
+	}
+	pc.freeIdentsExpr(node.One(exprTag).(ast.Branch), cloneBound(bound, names...), free)
+}
+
+// import //pkg as alias; body binds alias, per compileImportAs; the package
+// reference itself contributes no free identifiers.
+func (pc ParseContext) freeIdentsImportAs(c ast.Children, bound, free map[string]struct{}) {
+	node := c.(ast.One).Node.(ast.Branch)
+	alias := node.One("alias").One("IDENT").One("").(ast.Leaf).Scanner().String()
+	pc.freeIdentsExpr(node.One(exprTag).(ast.Branch), cloneBound(bound, alias), free)
+}
+
+func (pc ParseContext) freeIdentsWithOp(b ast.Branch, c ast.Children, bound, free map[string]struct{}) {
+	pc.freeIdentsExpr(b.One(exprTag).(ast.Branch), bound, free)
+	for _, withop := range c.(ast.Many) {
+		branch := withop.(ast.Branch)
+		if update := branch.One("arrayUpdate"); update != nil {
+			updateBranch := update.(ast.Branch)
+			pc.freeIdentsExpr(updateBranch.One("index").(ast.Branch), bound, free)
+			pc.freeIdentsExpr(updateBranch.One("value").(ast.Branch), bound, free)
+			continue
+		}
+		pc.freeIdentsExpr(branch.One("value").(ast.Branch), bound, free)
+	}
+}
+
+// compileMatches discards any bindings its pattern would introduce, so they
+// apply only within the pattern's own sub-expressions (fallbacks, equality
+// tests), never to anything beyond the matches node.
+func (pc ParseContext) freeIdentsMatches(b ast.Branch, bound, free map[string]struct{}) {
+	pc.freeIdentsExpr(b.One(exprTag).(ast.Branch), bound, free)
+	pc.patternBoundAndFree(b.One("matches").(ast.Branch).One("pattern").(ast.Branch), bound, free)
+}
+
+func (pc ParseContext) freeIdentsIf(b ast.Branch, c ast.Children, bound, free map[string]struct{}) {
+	pc.freeIdentsExpr(b.One(exprTag).(ast.Branch), bound, free)
+	for _, ifelse := range c.(ast.Many) {
+		branch := ifelse.(ast.Branch)
+		pc.freeIdentsExpr(branch.One("t").(ast.Branch), bound, free)
+		if f := branch.One("f"); f != nil {
+			pc.freeIdentsExpr(f.(ast.Branch), bound, free)
+		}
+	}
+}
+
+func (pc ParseContext) freeIdentsCond(c ast.Children, bound, free map[string]struct{}) {
+	node := c.(ast.One).Node.(ast.Branch)
+	if controlVar := node["controlVar"]; controlVar != nil {
+		pc.freeIdentsExpr(controlVar.(ast.One).Node.(ast.Branch), bound, free)
+		conditions := node["condition"].(ast.Many)
+		values := node["value"].(ast.Many)
+		for i, condition := range conditions {
+			names := pc.patternBoundAndFree(condition.(ast.Branch), bound, free)
+			pc.freeIdentsCondValue(values[i], cloneBound(bound, names...), free)
+		}
+		return
+	}
+	for _, pair := range node.Many("pairs") {
+		pc.freeIdentsExpr(pair.One("key").(ast.Branch), bound, free)
+		pc.freeIdentsExpr(pair.One("value").(ast.Branch), bound, free)
+	}
+}
+
+func (pc ParseContext) freeIdentsCondValue(node ast.Node, bound, free map[string]struct{}) {
+	name, c := which(node.(ast.Branch), exprTag)
+	if c == nil || name != exprTag {
+		return
+	}
+	switch c := c.(type) {
+	case ast.One:
+		pc.freeIdentsExpr(c.Node.(ast.Branch), bound, free)
+	case ast.Many:
+		for _, e := range c {
+			pc.freeIdentsExpr(e.(ast.Branch), bound, free)
+		}
+	}
+}
+
+// compileIfLet's pattern bindings are visible only within then, not f.
+func (pc ParseContext) freeIdentsIfLet(c ast.Children, bound, free map[string]struct{}) {
+	b := c.(ast.One).Node.(ast.Branch)
+	pc.freeIdentsExpr(b.One("controlVar").(ast.Branch), bound, free)
+	names := pc.patternBoundAndFree(b.One("pattern").(ast.Branch), bound, free)
+	pc.freeIdentsExpr(b.One("then").(ast.Branch), cloneBound(bound, names...), free)
+	if f := b.One("f"); f != nil {
+		pc.freeIdentsExpr(f.(ast.Branch), bound, free)
+	}
+}
+
+func (pc ParseContext) freeIdentsReduceWhile(c ast.Children, bound, free map[string]struct{}) {
+	b := c.(ast.One).Node.(ast.Branch)
+	pc.freeIdentsExpr(b.One("init").(ast.Branch), bound, free)
+	pc.freeIdentsExpr(b.One("cond").(ast.Branch), bound, free)
+	pc.freeIdentsExpr(b.One("step").(ast.Branch), bound, free)
+	if capNode := b.One("cap"); capNode != nil {
+		pc.freeIdentsExpr(capNode.(ast.Branch), bound, free)
+	}
+}
+
+func (pc ParseContext) freeIdentsTry(c ast.Children, bound, free map[string]struct{}) {
+	b := c.(ast.One).Node.(ast.Branch)
+	pc.freeIdentsExpr(b.One("body").(ast.Branch), bound, free)
+	pc.freeIdentsExpr(b.One("handler").(ast.Branch), bound, free)
+}
+
+func (pc ParseContext) freeIdentsCallGet(b ast.Branch, bound, free map[string]struct{}) {
+	if expr := b.One(exprTag); expr != nil {
+		pc.freeIdentsExpr(expr.(ast.Branch), bound, free)
+	}
+	// A bare get's own dot-target is a field name, not a variable reference.
+	for _, part := range b.Many("tail_op") {
+		if safe := part.One("safe_tail"); safe != nil {
+			pc.freeIdentsSafeTail(safe.(ast.Branch), bound, free)
+		} else {
+			pc.freeIdentsTail(part.One("tail"), bound, free)
+		}
+	}
+}
+
+func (pc ParseContext) freeIdentsTail(tail ast.Node, bound, free map[string]struct{}) {
+	if tail == nil {
+		return
+	}
+	if call := tail.One("call"); call != nil {
+		for _, arg := range call.Many("arg") {
+			argBranch := arg.(ast.Branch)
+			if e := argBranch.One("expr"); e != nil {
+				pc.freeIdentsExpr(e.(ast.Branch), bound, free)
+			}
+			if e := argBranch.One("end"); e != nil {
+				pc.freeIdentsExpr(e.(ast.Branch), bound, free)
+			}
+			if e := argBranch.One("step"); e != nil {
+				pc.freeIdentsExpr(e.(ast.Branch), bound, free)
+			}
+		}
+	}
+	// tail.One("get")'s name/renames/names targets are field names, not
+	// variable references.
+}
+
+func (pc ParseContext) freeIdentsSafeTail(tail ast.Branch, bound, free map[string]struct{}) {
+	pc.freeIdentsTailFunc(tail.One("first_safe").One("tail"), bound, free)
+	pc.freeIdentsExpr(tail.One("fall").(ast.Branch), bound, free)
+	for _, o := range tail.Many("ops") {
+		if safeTail := o.One("safe"); safeTail != nil {
+			pc.freeIdentsTailFunc(safeTail.(ast.Branch).One("tail"), bound, free)
+		} else if t := o.One("tail"); t != nil {
+			pc.freeIdentsTailFunc(t, bound, free)
+		}
+	}
+}
+
+func (pc ParseContext) freeIdentsTailFunc(tail ast.Node, bound, free map[string]struct{}) {
+	if tail == nil {
+		return
+	}
+	if call := tail.One("call"); call != nil {
+		for _, arg := range call.Many("arg") {
+			if e := arg.One("expr"); e != nil {
+				pc.freeIdentsExpr(e.(ast.Branch), bound, free)
+			}
+		}
+	}
+	// tail.One("get") is a field access target, not a variable reference.
+}
+
+func (pc ParseContext) freeIdentsRelation(c ast.Children, bound, free map[string]struct{}) {
+	relBranch := c.(ast.One).Node.(ast.Branch)
+	if tuples, has := relBranch["tuple"]; has {
+		for _, tuple := range tuples.(ast.Many) {
+			if vs, has := tuple.(ast.Branch)["v"]; has {
+				for _, v := range vs.(ast.Many) {
+					pc.freeIdentsExpr(v.(ast.Branch), bound, free)
+				}
+			}
+		}
+	}
+}
+
+func (pc ParseContext) freeIdentsSet(c ast.Children, bound, free map[string]struct{}) {
+	if elts := c.(ast.One).Node.(ast.Branch)["elt"]; elts != nil {
+		for _, e := range elts.(ast.Many) {
+			pc.freeIdentsExpr(e.(ast.Branch), bound, free)
+		}
+	}
+}
+
+func (pc ParseContext) freeIdentsDict(c ast.Children, bound, free map[string]struct{}) {
+	b := c.(ast.One).Node.(ast.Branch)
+	for _, pair := range b.Many("pairs") {
+		pc.freeIdentsExpr(pair.One("key").(ast.Branch), bound, free)
+		pc.freeIdentsExpr(pair.One("value").(ast.Branch), bound, free)
+	}
+}
+
+func (pc ParseContext) freeIdentsSparseItems(c ast.Children, bound, free map[string]struct{}) {
+	node := c.(ast.One).Node
+	var nodes []ast.Node
+	if firstItem := node.One("first_item"); firstItem != nil {
+		nodes = []ast.Node{firstItem}
+		if items := node.Many("item"); items != nil {
+			nodes = append(nodes, items...)
+		}
+	}
+	for _, expr := range nodes {
+		if expr.One("empty") != nil {
+			continue
+		}
+		pc.freeIdentsExpr(expr.(ast.Branch), bound, free)
+	}
+}
+
+func (pc ParseContext) freeIdentsXstr(c ast.Children, bound, free map[string]struct{}) {
+	for _, part := range c.(ast.One).Node.Many("part") {
+		if sexpr := part.(ast.Branch).One("sexpr"); sexpr != nil {
+			if e := sexpr.(ast.Branch).One("expr"); e != nil {
+				pc.freeIdentsExpr(e.(ast.Branch), bound, free)
+			}
+		}
+	}
+}
+
+func (pc ParseContext) freeIdentsFunction(b ast.Branch, bound, free map[string]struct{}) {
+	names := pc.patternBoundAndFree(b, bound, free)
+	pc.freeIdentsExpr(b.One(exprTag).(ast.Branch), cloneBound(bound, names...), free)
+}
+
+func (pc ParseContext) freeIdentsTuple(c ast.Children, bound, free map[string]struct{}) {
+	for _, pair := range c.(ast.One).Node.Many("pairs") {
+		p := pair.(ast.Branch)
+		if computed := p.One("computed"); computed != nil {
+			pc.freeIdentsExpr(computed.(ast.Branch).One(exprTag).(ast.Branch), bound, free)
+		}
+		vBound := bound
+		if p.One("rec") != nil {
+			if name := p.One("name"); name != nil {
+				vBound = cloneBound(bound, parseName(name.(ast.Branch)))
+			}
+		}
+		pc.freeIdentsExpr(p.One("v").(ast.Branch), vBound, free)
+	}
+}
+
+// patternBoundAndFree walks a pattern AST branch b the same way compilePattern
+// dispatches on it, returning the new names it binds and recording into free
+// any identifier referenced by the pattern's own sub-expressions (equality
+// tests in parenthesized/literal patterns, fallback defaults), resolved
+// against baseBound, the scope in effect before this pattern's own bindings
+// take effect.
+func (pc ParseContext) patternBoundAndFree(b ast.Branch, baseBound, free map[string]struct{}) []string {
+	if ptn := b.One("pattern"); ptn != nil {
+		return pc.patternBoundAndFree(ptn.(ast.Branch), baseBound, free)
+	}
+	if kind := b.One("kind"); kind != nil {
+		// The kind target (e.g. "number" in `::number n`) names a fixed kind,
+		// not a variable.
+		return pc.patternBoundAndFree(b.One("inner").(ast.Branch), baseBound, free)
+	}
+	if arr := b.One("array"); arr != nil {
+		return pc.sparsePatternsBoundAndFree(arr.(ast.Branch), baseBound, free)
+	}
+	if tuple := b.One("tuple"); tuple != nil {
+		return pc.tuplePatternBoundAndFree(tuple.(ast.Branch), baseBound, free)
+	}
+	if dict := b.One("dict"); dict != nil {
+		return pc.dictPatternBoundAndFree(dict.(ast.Branch), baseBound, free)
+	}
+	if set := b.One("set"); set != nil {
+		var bound []string
+		if elts := set.(ast.Branch)["elt"]; elts != nil {
+			for _, e := range elts.(ast.Many) {
+				bound = append(bound, pc.patternBoundAndFree(e.(ast.Branch), baseBound, free)...)
+			}
+		}
+		return bound
+	}
+	if extra := b.One("extra"); extra != nil {
+		if id := extra.(ast.Branch).One("ident"); id != nil {
+			return []string{id.Scanner().String()}
+		}
+		return nil
+	}
+	if exprs := b.Many("exprpattern"); exprs != nil {
+		// A parenthesized or STR pattern is an equality test against the
+		// enclosing scope, not a binding: recurse as an ordinary expr.
+		for _, e := range exprs {
+			pc.freeIdentsExpr(e.(ast.Branch), baseBound, free)
+		}
+		return nil
+	}
+
+	// The only remaining shapes compilePattern's fallback reaches are a bare
+	// IDENT (a genuine new binding) or a bare NUM literal (an equality test
+	// contributing no identifiers).
+	if name, c := which(b, "IDENT"); name == "IDENT" {
+		return []string{identName(c)}
+	}
+	return nil
+}
+
+func (pc ParseContext) sparsePatternsBoundAndFree(b ast.Branch, baseBound, free map[string]struct{}) []string {
+	var nodes []ast.Node
+	if firstItem, exists := b["first_item"]; exists {
+		nodes = []ast.Node{firstItem.(ast.One).Node}
+		if items, exists := b["item"]; exists {
+			nodes = append(nodes, items.(ast.Many)...)
+		}
+	}
+	var bound []string
+	for _, expr := range nodes {
+		if expr.One("empty") != nil {
+			continue
+		}
+		bound = append(bound, pc.patternBoundAndFree(expr.(ast.Branch), baseBound, free)...)
+		if fall := expr.One("fall"); fall != nil {
+			pc.freeIdentsExpr(fall.(ast.Branch), baseBound, free)
+		}
+	}
+	return bound
+}
+
+func (pc ParseContext) tuplePatternBoundAndFree(b ast.Branch, baseBound, free map[string]struct{}) []string {
+	var bound []string
+	for _, pair := range b.Many("pairs") {
+		if extra := pair.One("extra"); extra != nil {
+			bound = append(bound, pc.patternBoundAndFree(pair.(ast.Branch), baseBound, free)...)
+			continue
+		}
+		v := pair.One("v").(ast.Branch)
+		bound = append(bound, pc.patternBoundAndFree(v, baseBound, free)...)
+		if fall := v.One("fall"); fall != nil {
+			pc.freeIdentsExpr(fall.(ast.Branch), baseBound, free)
+		}
+	}
+	return bound
+}
+
+func (pc ParseContext) dictPatternBoundAndFree(b ast.Branch, baseBound, free map[string]struct{}) []string {
+	var bound []string
+	for _, pair := range b.Many("pairs") {
+		if extra := pair.One("extra"); extra != nil {
+			bound = append(bound, pc.patternBoundAndFree(pair.(ast.Branch), baseBound, free)...)
+			continue
+		}
+		pc.freeIdentsExpr(pair.One("key").(ast.Branch), baseBound, free)
+		value := pair.One("value").(ast.Branch)
+		bound = append(bound, pc.patternBoundAndFree(value, baseBound, free)...)
+		if fall := value.One("fall"); fall != nil {
+			pc.freeIdentsExpr(fall.(ast.Branch), baseBound, free)
+		}
+	}
+	return bound
+}
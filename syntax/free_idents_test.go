@@ -0,0 +1,79 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/arr-ai/arrai/rel"
+)
+
+func compileForFreeIdents(t *testing.T, code string) rel.Expr {
+	t.Helper()
+	pc := ParseContext{}
+	b, err := pc.Parse(parser.NewScanner(code))
+	require.NoError(t, err)
+	return pc.CompileExpr(b)
+}
+
+func TestFreeIdentsClosesOverOuterName(t *testing.T) {
+	t.Parallel()
+
+	expr := compileForFreeIdents(t, `\x x + y`)
+	assert.Equal(t, []string{"y"}, FreeIdents(expr))
+}
+
+func TestFreeIdentsNoOuterName(t *testing.T) {
+	t.Parallel()
+
+	expr := compileForFreeIdents(t, `\x x + 1`)
+	assert.Nil(t, FreeIdents(expr))
+}
+
+func TestFreeIdentsShadowing(t *testing.T) {
+	t.Parallel()
+
+	// The inner lambda's own x shadows the outer let's x, so only y is free.
+	assert.Equal(t, []string{"y"},
+		FreeIdents(compileForFreeIdents(t, `let x = 1; \x x + y`)))
+
+	// let (without rec) doesn't see its own binding within the value expr.
+	assert.Equal(t, []string{"x"},
+		FreeIdents(compileForFreeIdents(t, `let x = x + 1; x`)))
+
+	// let rec does see its own binding within the value expr.
+	assert.Nil(t, FreeIdents(compileForFreeIdents(t, `let rec f = \n cond n {0: 1, _: n * f(n - 1)}; f`)))
+
+	// Tuple and dict destructuring bind their component names.
+	assert.Nil(t, FreeIdents(compileForFreeIdents(t, `let (a: x, b: y) = (a: 1, b: 2); x + y`)))
+
+	// cond with a control var binds the clause pattern's names within that
+	// clause's value only.
+	assert.Equal(t, []string{"z"},
+		FreeIdents(compileForFreeIdents(t, `cond (a: 1) {(a: x): x + z, _: z}`)))
+
+	// matches discards any bindings its pattern would introduce.
+	assert.Equal(t, []string{"x"}, FreeIdents(compileForFreeIdents(t, `x ~ (a: y)`)))
+}
+
+func TestFreeIdentsUnionDef(t *testing.T) {
+	t.Parallel()
+
+	// The union's constructors are bound within its body; ctor params are
+	// local to each ctor's own curried function.
+	assert.Equal(t, []string{"x"},
+		FreeIdents(compileForFreeIdents(t, `|Shape| Circle(r) | Square(s); Circle(x)`)))
+	assert.Nil(t, FreeIdents(compileForFreeIdents(t, `|Shape| Circle(r); Circle(1)`)))
+}
+
+func TestFreeIdentsImportAs(t *testing.T) {
+	t.Parallel()
+
+	// The alias is bound within the body; the package reference itself
+	// contributes no free identifiers.
+	assert.Equal(t, []string{"y"},
+		FreeIdents(compileForFreeIdents(t, `import //math as m; m.pi + y`)))
+	assert.Nil(t, FreeIdents(compileForFreeIdents(t, `import //math as m; m.pi`)))
+}
@@ -2,17 +2,293 @@ package syntax
 
 import (
 	"fmt"
+	"io"
+	"io/fs"
 	"log"
+	"math/rand"
+	"os"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/arr-ai/wbnf/ast"
 	"github.com/arr-ai/wbnf/wbnf"
 
+	"github.com/spf13/afero"
+
 	"github.com/arr-ai/arrai/rel"
 	"github.com/arr-ai/wbnf/parser"
 )
 
 type ParseContext struct {
 	SourceDir string
+
+	// DisallowEval, when true, causes compilation of the `*` (eval) unop to
+	// fail with a compile error. Useful when compiling untrusted input,
+	// where dynamically constructed code must not be allowed to run.
+	DisallowEval bool
+
+	// StringLiteralHook, if set, is consulted by compileString for every
+	// string literal, with the literal's unescaped value and source
+	// position. Returning a non-nil expr replaces the literal in the
+	// compiled output, e.g. with a lookup into a message catalog.
+	// Returning nil compiles the literal as usual. This allows building
+	// compile passes such as i18n extractors or string-rewriting linters.
+	StringLiteralHook func(s string, pos parser.Scanner) rel.Expr
+
+	// AttachFunctionIDs, when true, causes compileFunction to attach a
+	// content-hash-based ID (over the function's source span) to every
+	// compiled *rel.Function, retrievable via its ID() method. The hash is
+	// stable across compilations given identical source, so hosts can use
+	// it to key caches or memoize across evaluations.
+	AttachFunctionIDs bool
+
+	// NumberGroupSeparator is the thousands-separator inserted by the `,`
+	// xstr format flag (e.g. `${n:,}`), such as "," for 1,234,567. Defaults
+	// to "," when empty, supporting locales that group digits differently
+	// (e.g. "." or a thin space) by overriding it.
+	NumberGroupSeparator string
+
+	// StrictRebinding, when true, makes it a compile error for a `let` or
+	// `letm` to rebind a name already bound earlier in the same chain of
+	// directly-sequential let statements, e.g. `let x = 1; let x = 2; x`,
+	// rather than silently shadowing it. The error points at both bindings.
+	// A let reached through a nested scope, such as a lambda body, still
+	// starts fresh and may shadow freely.
+	StrictRebinding bool
+
+	// StrictLiteralKinds, when true, makes compileArray and compileSet warn
+	// when a literal's elements mix kinds, e.g. `[1, (a: 1)]`, which is
+	// usually a mistake in a data file rather than intentional
+	// heterogeneity. Elements that aren't themselves literals (anything
+	// more dynamic than a bare NUM/STR/CHAR/IDENT true/false or a nested
+	// tuple/array/set/dict/rel/bytes literal) are exempt, since their kind
+	// can't be known at compile time.
+	StrictLiteralKinds bool
+
+	// DebugSink, if set, is called by each `debug(label, expr)` tap as it's
+	// reached during evaluation, with the evaluated label and value, e.g.
+	// to log them. If unset, `debug` is still a no-op identity tap: expr is
+	// evaluated and passed through, but nothing is reported.
+	DebugSink func(label string, value rel.Value)
+
+	// LiteralKindWarning, if set, is called for each StrictLiteralKinds
+	// warning with a message naming the differing kinds and the source
+	// positions of both elements, instead of the default of logging the
+	// warning via log.Error. Useful for collecting warnings in tests or
+	// surfacing them through a different channel than the log.
+	LiteralKindWarning func(msg string, pos parser.Scanner)
+
+	// BuildTags is the set of tags available to `# arrai:build TAG` /
+	// `# arrai:endbuild` conditional-compilation regions: a region whose
+	// build line's TAG (or, prefixed with "!", whose TAG is absent) doesn't
+	// match is blanked out of the source before parsing, so it's never
+	// compiled and may even contain syntax that wouldn't otherwise parse.
+	// Applied by ParseString and MustParseString; bare Parse/MustParse,
+	// taking an already-constructed Scanner, do not apply it, since doing
+	// so would disrupt position tracking for scanners already mid-parse
+	// (e.g. a macro's embedded subgrammar). The package-level Compile and
+	// MustCompile entry points build their own ParseContext and so don't
+	// expose this field; call ParseContext.MustParseString directly (then
+	// CompileExpr the result) to opt in.
+	BuildTags []string
+
+	// Clock, if set, is called by each compiled `now()` to obtain the
+	// current time, e.g. a fixed clock for deterministic tests. Defaults to
+	// the real system clock (time.Now) when nil.
+	Clock func() time.Time
+
+	// DisallowAmbientTime, when true, makes compilation of `now()` fail
+	// with a compile error. Useful for compiling untrusted or sandboxed
+	// input that must evaluate deterministically, with no ambient access
+	// to wall-clock time.
+	DisallowAmbientTime bool
+
+	// IDGenerator, if set, is called by each compiled `genid(seed)` with
+	// seed to produce an ID string, e.g. a fixed mapping for deterministic
+	// tests. Defaults to a built-in seeded generator when nil.
+	IDGenerator func(seed int64) string
+
+	// DisallowAmbientRandomness, when true, makes compilation of
+	// `genid(seed)`, `rand(seed)` and `shuffle(arr, seed)` fail with a
+	// compile error. Useful for compiling untrusted or sandboxed input
+	// that must evaluate deterministically, with no ambient access to a
+	// source of randomness.
+	DisallowAmbientRandomness bool
+
+	// RandSource, if set, is called by each compiled `rand(seed)` or
+	// `shuffle(arr, seed)` with seed to obtain a *rand.Rand, e.g. a fixed
+	// source for deterministic tests. Defaults to
+	// rand.New(rand.NewSource(seed)) when nil.
+	RandSource func(seed int64) *rand.Rand
+
+	// EnvLookup, if set, is called by each compiled `env(name, default)`
+	// with name to look up an environment variable, e.g. a fixed map for
+	// deterministic tests. Defaults to os.LookupEnv when nil.
+	EnvLookup func(name string) (string, bool)
+
+	// DisallowAmbientEnv, when true, makes compilation of `env(name, default)`
+	// fail with a compile error. Useful for compiling untrusted or sandboxed
+	// input that must evaluate deterministically, with no ambient access to
+	// the process environment.
+	DisallowAmbientEnv bool
+
+	// FS, if set, is used by each compiled `glob(pattern)` to list matching
+	// file paths, e.g. an in-memory afero.Fs for deterministic tests.
+	// Defaults to the OS filesystem when nil.
+	FS afero.Fs
+
+	// DisallowAmbientFS, when true, makes compilation of `glob(pattern)`,
+	// `readFile(path)` and `readFileStr(path)` fail with a compile error.
+	// Useful for compiling untrusted or sandboxed input that must evaluate
+	// deterministically, with no ambient access to the filesystem.
+	DisallowAmbientFS bool
+
+	// ReadFS, if set, is used by each compiled `readFile(path)` and
+	// `readFileStr(path)` to read file contents, e.g. an fstest.MapFS for
+	// deterministic tests or a restricted view for sandboxing. Defaults to
+	// the OS filesystem, rooted at "/", when nil.
+	ReadFS fs.FS
+
+	// Sinks maps named sinks for compiled `write(sink, value)` calls to
+	// io.Writers, e.g. an in-memory buffer for tests. The built-in "stdout"
+	// and "stderr" sinks (os.Stdout and os.Stderr) are always available
+	// unless overridden by an entry here. Compiling a `write` to any other
+	// sink name is a compile error.
+	Sinks map[string]io.Writer
+
+	// StrictUnionExhaustiveness, when true, makes it a compile error for a
+	// `cond controlVar { ... }` compiled within a uniondef's own body (see
+	// compileUnionDef) to omit one of the union's variants without a
+	// catch-all (bare identifier) pattern, rather than just warning. Only
+	// cond forms reached from within the uniondef's body are checked,
+	// since that's the only scope in which the set of variants is known
+	// statically.
+	StrictUnionExhaustiveness bool
+
+	// UnionExhaustivenessWarning, if set, is called for each union
+	// exhaustiveness warning (raised when StrictUnionExhaustiveness is
+	// false; when true, the same case is a compile error instead) with a
+	// message naming the missing
+	// variant(s) and the source position of the cond, instead of the
+	// default of logging the warning via log.Error. Useful for collecting
+	// warnings in tests or surfacing them through a different channel
+	// than the log.
+	UnionExhaustivenessWarning func(msg string, pos parser.Scanner)
+
+	// activeUnionVariants holds the variant (constructor) names
+	// introduced by the innermost enclosing uniondef, so a cond within
+	// its body can be checked for exhaustiveness against them. nil
+	// outside any uniondef's body.
+	activeUnionVariants []string
+}
+
+// buildDirective matches a `# arrai:build TAG` line, capturing its
+// (optionally "!"-negated) tag.
+var buildDirective = regexp.MustCompile(`^[ \t]*#[ \t]*arrai:build[ \t]+(!?\S+)[ \t]*$`)
+
+// endBuildDirective matches a `# arrai:endbuild` line.
+var endBuildDirective = regexp.MustCompile(`^[ \t]*#[ \t]*arrai:endbuild[ \t]*$`)
+
+// applyBuildTags blanks out, line by line (preserving line and column
+// numbers for whatever remains), every `# arrai:build TAG` ... `#
+// arrai:endbuild` region whose TAG isn't in tags (or, negated with "!",
+// whose TAG is), along with the directive lines themselves. It panics,
+// like the rest of this package's syntactic validation, if a build line
+// has no matching endbuild.
+//
+// Directives are matched against raw source lines, before tokenizing, with
+// one exception: lines that begin inside a STR literal opened (and not yet
+// closed) on an earlier line are never matched, so a multi-line string
+// literal whose content happens to look like a directive is left alone (see
+// linesInOpenString). A directive embedded elsewhere within a line that
+// also contains non-string content -- e.g. following a string literal that
+// opens and closes earlier on the same line -- is not specially handled,
+// since buildDirective and endBuildDirective already require the directive
+// to be the whole line (other than leading whitespace).
+func applyBuildTags(source string, tags []string) string {
+	tagSet := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tagSet[tag] = true
+	}
+	blank := func(line string) string {
+		return strings.Repeat(" ", len(line))
+	}
+
+	lines := strings.Split(source, "\n")
+	inString := linesInOpenString(source)
+	for i := 0; i < len(lines); i++ {
+		if inString[i] {
+			continue
+		}
+		m := buildDirective.FindStringSubmatch(lines[i])
+		if m == nil {
+			continue
+		}
+		tag := m[1]
+		included := tagSet[tag]
+		if strings.HasPrefix(tag, "!") {
+			included = !tagSet[tag[1:]]
+		}
+		start := i
+		lines[i] = blank(lines[i])
+		for i++; i < len(lines) && !(!inString[i] && endBuildDirective.MatchString(lines[i])); i++ {
+			if !included {
+				lines[i] = blank(lines[i])
+			}
+		}
+		if i == len(lines) {
+			panic(fmt.Errorf("arrai:build at line %d has no matching arrai:endbuild", start+1))
+		}
+		lines[i] = blank(lines[i])
+	}
+	return strings.Join(lines, "\n")
+}
+
+// strQuoteChars are STR's three quote characters (see the STR rule in
+// arrai.wbnf): double quote and single quote support backslash-escapes;
+// backtick (U+2035, reversed prime) instead escapes itself by doubling.
+const (
+	strDoubleQuote = '"'
+	strSingleQuote = '\''
+	strBacktick    = '‵'
+)
+
+// linesInOpenString reports, for each line of source (split on "\n"),
+// whether that line begins inside a STR literal opened on an earlier line
+// and not yet closed by the start of this line. applyBuildTags uses this to
+// avoid mistaking a build directive appearing in a multi-line string
+// literal's own content for a real one.
+func linesInOpenString(source string) []bool {
+	inString := make([]bool, 0, strings.Count(source, "\n")+1)
+	var quote rune
+	open := false
+	for _, line := range strings.Split(source, "\n") {
+		inString = append(inString, open)
+		runes := []rune(line)
+		for i := 0; i < len(runes); i++ {
+			r := runes[i]
+			if !open {
+				if r == strDoubleQuote || r == strSingleQuote || r == strBacktick {
+					open, quote = true, r
+				}
+				continue
+			}
+			switch {
+			case quote != strBacktick && r == '\\':
+				i++ // Backslash escapes the next char; skip over both.
+			case quote == strBacktick && r == strBacktick:
+				if i+1 < len(runes) && runes[i+1] == strBacktick {
+					i++ // Doubled backtick: a literal backtick; string stays open.
+				} else {
+					open = false
+				}
+			case quote != strBacktick && r == quote:
+				open = false
+			}
+		}
+	}
+	return inString
 }
 
 func parseNames(names ast.Branch) []string {
@@ -24,6 +300,26 @@ func parseNames(names ast.Branch) []string {
 	return result
 }
 
+// parseRenames parses a `|new::old, ...|` renames node into its (new, old)
+// pairs, panicking with a compile error if two pairs rename to the same
+// new name.
+func parseRenames(renames ast.Branch) []rel.TupleProjectRename {
+	pairs := renames.Many("pair")
+	result := make([]rel.TupleProjectRename, 0, len(pairs))
+	seen := make(map[string]struct{}, len(pairs))
+	for _, pair := range pairs {
+		branch := pair.(ast.Branch)
+		newName := branch.One("new").One("IDENT").One("").(ast.Leaf).Scanner().String()
+		oldName := branch.One("old").One("IDENT").One("").(ast.Leaf).Scanner().String()
+		if _, dup := seen[newName]; dup {
+			panic(fmt.Errorf("rename: duplicate attr name: %q", newName))
+		}
+		seen[newName] = struct{}{}
+		result = append(result, rel.TupleProjectRename{New: newName, Old: oldName})
+	}
+	return result
+}
+
 func parseName(name ast.Branch) string {
 	ktype, children := which(name, "IDENT", "STR")
 	switch ktype {
@@ -39,7 +335,7 @@ func parseName(name ast.Branch) string {
 
 // MustParseString parses input string and returns the parsed Expr or panics.
 func (pc ParseContext) MustParseString(s string) ast.Branch {
-	return pc.MustParse(parser.NewScanner(s))
+	return pc.MustParse(parser.NewScanner(applyBuildTags(s, pc.BuildTags)))
 }
 
 // MustParse parses input and returns the parsed Expr or panics.
@@ -53,7 +349,7 @@ func (pc ParseContext) MustParse(s *parser.Scanner) ast.Branch {
 
 // ParseString parses input string and returns the parsed Expr or an error.
 func (pc ParseContext) ParseString(s string) (ast.Branch, error) {
-	return pc.Parse(parser.NewScanner(s))
+	return pc.Parse(parser.NewScanner(applyBuildTags(s, pc.BuildTags)))
 }
 
 // Parse parses input and returns the parsed Expr or an error.
@@ -161,6 +457,24 @@ func (pc ParseContext) Parse(s *parser.Scanner) (ast.Branch, error) {
 	return result, nil
 }
 
+// resolveSink returns the io.Writer for a `write(sink, value)` sink name, or
+// nil if name is not a recognized sink.
+func (pc ParseContext) resolveSink(name string) io.Writer {
+	if pc.Sinks != nil {
+		if w, ok := pc.Sinks[name]; ok {
+			return w
+		}
+	}
+	switch name {
+	case "stdout":
+		return os.Stdout
+	case "stderr":
+		return os.Stderr
+	default:
+		return nil
+	}
+}
+
 func parseNest(lhs rel.Expr, branch ast.Branch) rel.Expr {
 	attr := branch.One("IDENT").One("").Scanner()
 	namesBranch, exist := branch["names"]
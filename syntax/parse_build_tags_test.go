@@ -0,0 +1,103 @@
+package syntax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/arr-ai/arrai/rel"
+)
+
+// evalWithBuildTags parses and compiles code under the given build tags,
+// going through MustParseString so `# arrai:build` regions are applied, then
+// evaluates the result.
+func evalWithBuildTags(t *testing.T, tags []string, code string) rel.Value {
+	t.Helper()
+	pc := ParseContext{BuildTags: tags}
+	expr := pc.CompileExpr(pc.MustParseString(code))
+	value, err := expr.Eval(rel.EmptyScope)
+	require.NoError(t, err)
+	return value
+}
+
+// TestBuildTagsIncludesMatchingRegion checks that a `# arrai:build TAG`
+// region is compiled in when TAG is present in BuildTags, rebinding a name
+// bound earlier in the same source.
+func TestBuildTagsIncludesMatchingRegion(t *testing.T) {
+	t.Parallel()
+
+	code := `
+		let x = 1;
+		# arrai:build dev
+		let x = 2;
+		# arrai:endbuild
+		x
+	`
+	value := evalWithBuildTags(t, []string{"dev"}, code)
+	assert.True(t, value.Equal(rel.NewNumber(2)), "got %v", value)
+}
+
+// TestBuildTagsExcludesNonMatchingRegion checks that the same region is
+// blanked out of the source, as if never written, when TAG isn't in
+// BuildTags — even if it contains syntax that wouldn't otherwise parse.
+func TestBuildTagsExcludesNonMatchingRegion(t *testing.T) {
+	t.Parallel()
+
+	code := `
+		let x = 1;
+		# arrai:build dev
+		this is not valid arrai syntax <<<
+		# arrai:endbuild
+		x
+	`
+	value := evalWithBuildTags(t, nil, code)
+	assert.True(t, value.Equal(rel.NewNumber(1)), "got %v", value)
+}
+
+// TestBuildTagsNegatedTag checks that a `!TAG` build line includes its
+// region exactly when TAG is absent from BuildTags.
+func TestBuildTagsNegatedTag(t *testing.T) {
+	t.Parallel()
+
+	code := `
+		let x = 1;
+		# arrai:build !dev
+		let x = 2;
+		# arrai:endbuild
+		x
+	`
+	assert.True(t, evalWithBuildTags(t, nil, code).Equal(rel.NewNumber(2)))
+	assert.True(t, evalWithBuildTags(t, []string{"dev"}, code).Equal(rel.NewNumber(1)))
+}
+
+// TestBuildTagsMissingEndBuildPanics checks that an unterminated
+// `# arrai:build` region is a hard parse-time error rather than silently
+// extending to the end of the source.
+func TestBuildTagsMissingEndBuildPanics(t *testing.T) {
+	t.Parallel()
+
+	code := `
+		# arrai:build dev
+		let x = 1;
+		x
+	`
+	assert.Panics(t, func() {
+		(ParseContext{BuildTags: []string{"dev"}}).MustParseString(code)
+	})
+}
+
+// TestBuildTagsIgnoreDirectiveLooksInsideMultilineString checks that a
+// string literal's own content, even a line within it that happens to look
+// like a `# arrai:build`/`# arrai:endbuild` directive, is left untouched by
+// build-tag processing -- directives are only recognized outside of STR
+// literals.
+func TestBuildTagsIgnoreDirectiveLooksInsideMultilineString(t *testing.T) {
+	t.Parallel()
+
+	code := "let s = \"line one\n# arrai:build x\nline three\n# arrai:endbuild\nline five\"; s"
+	value := evalWithBuildTags(t, nil, code)
+	assert.True(t,
+		value.Equal(rel.NewString([]rune("line one\n# arrai:build x\nline three\n# arrai:endbuild\nline five"))),
+		"got %v", value)
+}
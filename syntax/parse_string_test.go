@@ -3,6 +3,11 @@ package syntax
 import (
 	"strings"
 	"testing"
+
+	"github.com/arr-ai/wbnf/parser"
+	"github.com/stretchr/testify/require"
+
+	"github.com/arr-ai/arrai/rel"
 )
 
 func TestXStringSimple(t *testing.T) {
@@ -15,6 +20,28 @@ func TestXStringSimple(t *testing.T) {
 	AssertCodesEvalToSameValue(t, `"a42k3.142z"     `, `$"a${6*7}k${//math.pi:.3f}z"`)
 }
 
+// TestXStringNumericGrouping checks the `,` format flag, which inserts a
+// thousands separator into a numeric expansion's integer part.
+func TestXStringNumericGrouping(t *testing.T) {
+	t.Parallel()
+	AssertCodesEvalToSameValue(t, `"1,234,567"`, `$"${1234567:,}"`)
+	AssertCodesEvalToSameValue(t, `"1,234,567"`, `$"${1234567:,d}"`)
+	AssertCodesEvalToSameValue(t, `"1,234,567.5"`, `$"${1234567.5:,.1f}"`)
+	AssertCodesEvalToSameValue(t, `"-1,234"`, `$"${-1234:,}"`)
+	AssertCodesEvalToSameValue(t, `"123"`, `$"${123:,}"`)
+
+	// ParseContext.NumberGroupSeparator configures an alternate locale's
+	// separator character, e.g. "." for groups.
+	pc := ParseContext{NumberGroupSeparator: "."}
+	b, err := pc.Parse(parser.NewScanner(`$"${1234567:,}"`))
+	require.NoError(t, err)
+	value, err := pc.CompileExpr(b).Eval(rel.Scope{})
+	require.NoError(t, err)
+	s, is := rel.AsString(value.(rel.Set))
+	require.True(t, is)
+	require.Equal(t, "1.234.567", s.String())
+}
+
 func TestXStringBackquote(t *testing.T) {
 	t.Parallel()
 	AssertCodesEvalToSameValue(t, `""      `, "$``")
@@ -15,38 +15,109 @@ var arraiParsers = wbnf.MustCompile(unfakeBackquote(`
 expr   -> C* amp="&"* @ C* arrow=(
               nest |
               unnest |
-              ARROW @ |
+              sample |
+              scan |
+              find |
+              ARROW @ nulls=(C* "nulls" C* order=/{first|last})? |
               FILTER cond=(controlVar=@ "{" (condition=pattern ":" value=@):SEQ_COMMENT,? "}") |
               binding="->" C* "\\" C* pattern C* %%bind C* @ |
               binding="->" C* %%bind @
           )* C*
         > C* @:binop=">>>" C*
         > C* unop=/{:>|=>|>>}* @ C*
-        > C* @:binop=("without" | "with") C*
+        > C* @ withop=(
+              arrayUpdate=("with" C* "[" C* index=expr C* "]" C* "=" C* value=@) |
+              op=("without" | "with") C* value=@
+          )* C*
         > C* @:binop="||" C*
         > C* @:binop="&&" C*
         > C* @:binop="+>" C*
         > C* @:compare=/{!?(?:<:|=|<=?|>=?|\((?:<=?|>=?|<>=?)\))} C*
+        > C* @ matches=("~" pattern)? C*
         > C* @ if=("if" t=expr ("else" f=expr)?)* C*
         > C* @:binop=/{\+\+|[+|]|-%?} C*
-        > C* @:binop=/{&~|&|~~?|[-<][-&][->]} C*
+        > C* @:binop=/{&~|&|~~|[-<][-&][->]} C*
         > C* @:binop=/{//|[*/%]|\\} C*
         > C* @:rbinop="^" C*
         > C* unop=/{[-+!*^]}* @ C*
-        > C* @ postfix=/{count|single}? C* touch? C*
+        > C* @ postfix=/{count\b|single\b}? C* touch? C* convert=("::" target=CONVERT_TARGET)? C*
         > C* (get | @) tail_op=(
-            safe_tail=(first_safe=(tail "?") ops=(safe=(tail "?") | tail)* ":" fall=@)
+            safe_tail=(first_safe=(tail "?") ops=(safe=(tail "?") | tail)* sep=/{\??:} fall=@)
             | tail
           )* C*
         > %!patternterms(expr)
         | C* cond=("cond" "{" pairs=(key=@ ":" value=@):SEQ_COMMENT,? "}") C*
         | C* cond=("cond" controlVar=expr "{" (condition=pattern ":" value=@):SEQ_COMMENT,? "}") C*
+        | C* iflet=("if" C* "let" C* pattern C* "=" C* controlVar=expr C* "{" C* then=expr C* "}"
+              C* ("else" C* "{" C* f=expr C* "}")? ) C*
+        | C* reduceWhile=("reduce" C* init=expr C* "while" C* cond=expr C* "do" C* step=expr
+              C* ("cap" C* cap=expr)? ) C*
+        | C* try=("try" C* body=expr C* "catch" C* kind=IDENT C* handler=expr) C*
+        | C* debug=("debug" C* "(" C* label=expr C* "," C* body=expr C* ")") C*
+        | C* hash=("hash" C* "(" C* value=expr C* ")") C*
+        | C* bool=("bool" C* "(" C* value=expr C* ")") C*
+        | C* charClass=(dir=/{isDigit|isLetter|isSpace} C* "(" C* c=expr C* ")") C*
+        | C* pad=(dir=/{padleft|padright} C* "(" C* s=expr C* "," C* width=expr C* "," C* ch=expr C* ")") C*
+        | C* replace=("replace" C* "(" C* s=expr C* "," C* pat=expr C* "," C* repl=expr C* ")") C*
+        | C* replaceLit=("replaceLit" C* "(" C* s=expr C* "," C* old=expr C* "," C* new=expr C*
+              ("," C* count=expr)? C* ")") C*
+        | C* trim=(dir=/{trimLeft|trimRight|trimPrefix|trimSuffix|trim} C* "(" C* s=expr C* "," C* arg=expr C* ")") C*
+        | C* parseNum=("parseNum" C* "(" C* s=expr C* "," C* base=expr C* ")") C*
+        | C* now=("now" C* "(" C* ")") C*
+        | C* genid=("genid" C* "(" C* seed=expr C* ")") C*
+        | C* rand=("rand" C* "(" C* seed=expr C* ")") C*
+        | C* shuffle=("shuffle" C* "(" C* arr=expr C* "," C* seed=expr C* ")") C*
+        | C* numtheory=(dir=/{gcd|lcm} C* "(" C* a=expr C* "," C* b=expr C* ")") C*
+        | C* roundFn=(dir=/{floor|ceil|truncate|round} C* "(" C* x=expr C* ("," C* places=expr)? C* ")") C*
+        | C* numHelper=(dir=/{abs|sign|pow10} C* "(" C* x=expr C* ")") C*
+        | C* formatTime=("formatTime" C* "(" C* t=expr C* "," C* layout=expr C* ")") C*
+        | C* parseTime=("parseTime" C* "(" C* s=expr C* "," C* layout=expr C* ")") C*
+        | C* duration=("duration" C* "(" C* s=expr C* ")") C*
+        | C* addDuration=("addDuration" C* "(" C* t=expr C* "," C* d=expr C* ")") C*
+        | C* timeDiff=("timeDiff" C* "(" C* a=expr C* "," C* b=expr C* ")") C*
+        | C* env=("env" C* "(" C* varName=expr C* ("," C* default=expr)? C* ")") C*
+        | C* glob=("glob" C* "(" C* globPattern=expr C* ")") C*
+        | C* readFile=(dir=/{readFileStr|readFile} C* "(" C* path=expr C* ")") C*
+        | C* write=("write" C* "(" C* sink=IDENT C* "," C* value=expr C* ")") C*
+        | C* diff=("diff" C* "(" C* a=expr C* "," C* b=expr C* ")") C*
+        | C* mergeDeep=("mergeDeep" C* "(" C* a=expr C* "," C* b=expr C* ")") C*
+        | C* getPath=("getPath" C* "(" C* value=expr C* "," C* path=expr C* ")") C*
+        | C* setPath=("setPath" C* "(" C* value=expr C* "," C* path=expr C* "," C* v=expr C* ")") C*
+        | C* indexOf=("indexOf" C* "(" C* a=expr C* "," C* v=expr C* ")") C*
+        | C* repeatStr=("repeatStr" C* "(" C* s=expr C* "," C* n=expr C* ")") C*
+        | C* caseConv=(dir=/{upper|lower|title} C* "(" C* s=expr C* ")") C*
+        | C* displayWidth=("displayWidth" C* "(" C* s=expr C* ")") C*
+        | C* wordWrap=("wordWrap" C* "(" C* s=expr C* "," C* width=expr C* ")") C*
+        | C* dedent=("dedent" C* "(" C* s=expr C* ")") C*
+        | C* indent=("indent" C* "(" C* s=expr C* "," C* prefix=expr C* ("," C* all=expr)? C* ")") C*
+        | C* toBase=("toBase" C* "(" C* n=expr C* "," C* base=expr C* ")") C*
+        | C* fromBase=("fromBase" C* "(" C* s=expr C* "," C* base=expr C* ")") C*
+        | C* bitCount=(dir=/{popcount|leadingZeros|trailingZeros} C* "(" C* n=expr C* ")") C*
+        | C* checksum=(dir=/{sha256|md5|crc32} C* "(" C* bytes=expr C* ")") C*
+        | C* hmacSha256=("hmacSha256" C* "(" C* key=expr C* "," C* message=expr C* ")") C*
+        | C* b64=(dir=/{base64encode|base64decode} C* "(" C* value=expr C* "," C* urlSafe=expr C* ")") C*
+        | C* jsonDecode=("jsonDecode" C* "(" C* s=expr C* ")") C*
+        | C* jsonEncode=("jsonEncode" C* "(" C* value=expr C* ")") C*
+        | C* csvDecode=("csvDecode" C* "(" C* s=expr C* "," C* header=expr C* "," C* delimiter=expr C* ")") C*
+        | C* xmlDecode=("xmlDecode" C* "(" C* s=expr C* ")") C*
+        | C* render=("render" C* "(" C* template=expr C* "," C* value=expr C* ")") C*
+        | C* record=("record" C* "(" C* fields=IDENT:"," C* ")") C*
+        | C* approxEqual=("approxEqual" C* "(" C* a=expr C* "," C* b=expr C* "," C* eps=expr C* ")") C*
+        | C* insertSorted=("insertSorted" C* "(" C* arr=expr C* "," C* v=expr C* ("," C* key=expr)? C* ")") C*
+        | C* memo=("memo" C* "(" C* body=expr C* ")") C*
+        | C* interleave=("interleave" C* "(" C* arrs=expr:SEQ_COMMENT,? C* ")") C*
+        | C* splitAt=("splitAt" C* "(" C* arr=expr C* "," C* n=expr C* ")") C*
         | C* "{:" C* embed=(macro=@ rule? ":" subgrammar=%%ast) ":}" C*
         | C* op="\\\\" @ C*
         | C* fn="\\" pattern @ C*
         | C* import="//" pkg=( "{" dot="."? PKGPATH "}" | std=IDENT?)
         | C* odelim="(" @ cdelim=")" C*
         | C* let=("let" C* rec="rec"? pattern C* "=" C* @ %%bind C* ";" C* @) C*
+        | C* letm=("let" C* bindings=(pattern C* "=" C* @ %%bind):"," C* ";" C* @) C*
+        | C* uniondef=("|" C* union=IDENT C* "|" C*
+              ctor=(ctorName=IDENT C* "(" C* params=IDENT:"," C* ")"):"|" C* ";" C* @) C*
+        | C* importAs=("import" C* slashes="//" C* pkg=( "{" dot="."? PKGPATH "}" | std=IDENT?)
+              C* "as" C* alias=IDENT C* ";" C* @) C*
         | C* xstr C*
         | C* IDENT C*
         | C* STR C*
@@ -55,16 +126,20 @@ expr   -> C* amp="&"* @ C* arrow=(
 rule   -> C* "[" C* name C* "]" C*;
 nest   -> C* "nest" names? IDENT C*;
 unnest -> C* "unnest" IDENT C*;
+sample -> C* "sample" C* "(" C* n=expr C* "," C* seed=expr C* ")" C*;
+scan   -> C* "scan" C* "(" C* init=expr C* "," C* step=expr C* ")" C*;
+find   -> C* "find" C* "(" C* pred=expr C* ("," C* fb=expr)? C* ")" C*;
 touch  -> C* ("->*" ("&"? IDENT | STR))+ "(" expr:"," ","? ")" C*;
-get    -> C* dot="." ("&"? IDENT | STR | "~"? names) C*;
-names  -> C* "|" C* IDENT:"," C* "|" C*;
+get     -> C* dot="." ("&"? IDENT | STR | "~"? names | renames) C*;
+names   -> C* "|" C* IDENT:"," C* "|" C*;
+renames -> C* "|" C* pair=(new=IDENT "::" old=IDENT):"," C* "|" C*;
 name   -> C* IDENT C* | C* STR C*;
 xstr   -> C* quote=/{\$"\s*} part=( sexpr | fragment=/{(?: \\. | \$[^{"] | [^\\"$] )+} )* '"' C*
         | C* quote=/{\$'\s*} part=( sexpr | fragment=/{(?: \\. | \$[^{'] | [^\\'$] )+} )* "'" C*
         | C* quote=/{\$‵\s*} part=( sexpr | fragment=/{(?: ‵‵  | \$[^{‵] | [^‵  $] )+} )* "‵" C*;
 sexpr  -> "${"
           C* expr C*
-          control=/{ (?: : [-+#*\.\_0-9a-z]* (?: : (?: \\. | [^\\:}] )* ){0,2} )? }
+          control=/{ (?: : [-+#*\.\_0-9a-z,]* (?: : (?: \\. | [^\\:}] )* ){0,2} )? }
           close=/{\}\s*};
 tail   -> get
           | call=("("
@@ -73,18 +148,20 @@ tail   -> get
                     |     ":" end=expr  (":" step=expr)?
                 ):SEQ_COMMENT,
             ")");
-pattern -> extra 
+pattern -> extra
+        | kind=("::" target=IDENT) inner=pattern
         | %!patternterms(pattern|expr)
         | IDENT
-        | NUM 
-        | C* "(" exprpattern=expr:SEQ_COMMENT,? ")" C* 
+        | NUM
+        | C* "(" exprpattern=expr:SEQ_COMMENT,? ")" C*
         | C* exprpattern=STR C*;
 extra -> ("..." ident=IDENT?);
 fallback -> ("?"? ":" fall=expr);
 
-ARROW  -> /{:>|=>|>>|orderby|order|rank|where|sum|max|mean|median|min};
+ARROW  -> /{:>|=>|>>|orderby|order|rank|where|countWhere|any|all|sum|max|mean|median|min|split|join|cross|distinctby|histogram|partition|contains|startsWith|endsWith|takeWhile|dropWhile};
 FILTER -> /{filter};
 IDENT  -> /{ \. | [$@A-Za-z_][0-9$@A-Za-z_]* };
+CONVERT_TARGET -> /{ [A-Za-z_][0-9A-Za-z_]* };
 PKGPATH -> /{ (?: \\ | [^\\}] )* };
 STR    -> /{ " (?: \\. | [^\\"] )* "
            | ' (?: \\. | [^\\'] )* '
@@ -98,12 +175,12 @@ SEQ_COMMENT -> "," C*;
 .wrapRE -> /{\s*()\s*};
 
 .macro patternterms(top) {
-    C* odelim="{" C* rel=(names tuple=("(" v=top:SEQ_COMMENT, ")"):SEQ_COMMENT,?) cdelim="}" C*
-  | C* odelim="{" C* set=(elt=top:SEQ_COMMENT,?) cdelim="}" C*
+    C* odelim="{" C* set=(elt=top:SEQ_COMMENT,?) cdelim="}" C*
+  | C* odelim="{" C* rel=(names? tuple=("(" v=top:SEQ_COMMENT, ")"):SEQ_COMMENT,?) cdelim="}" C*
   | C* odelim="{" C* dict=(pairs=((extra|key=(expr tail=("?")?) ":" value=(top fall=(":" expr)?))):SEQ_COMMENT,?) cdelim="}" C*
   | C* odelim="[" C* array=(%!sparse_sequence(tail=("?")? top fall=(":" expr)?)?) C* cdelim="]" C*
   | C* odelim="<<" C* bytes=(item=(STR|NUM|CHAR|IDENT|"("top")"):SEQ_COMMENT,?) C* cdelim=">>" C*
-  | C* odelim="(" tuple=(pairs=(extra | (((name tail="?") | rec="rec"? name | name?) ":" v=(top fall=(":" expr)?))):SEQ_COMMENT,?) cdelim=")" C*
+  | C* odelim="(" tuple=(pairs=(extra | (((name tail="?") | rec="rec"? name | computed=("::" expr) | name?) ":" v=(top fall=(":" expr)?))):SEQ_COMMENT,?) cdelim=")" C*
 };
 
 .macro sparse_sequence(top) {
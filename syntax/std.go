@@ -95,6 +95,7 @@ func StdScope() rel.Scope {
 					}),
 				),
 				stdArchive(),
+				stdConvert(),
 				stdEncoding(),
 				stdEval(),
 				stdOs(),
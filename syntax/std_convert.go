@@ -0,0 +1,63 @@
+package syntax
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/arr-ai/arrai/rel"
+)
+
+// convertTargets maps each supported `::target` conversion postfix to the
+// native function that implements it. It is also consulted at compile time
+// to reject unsupported targets (e.g. `::widget`) before evaluation.
+var convertTargets = map[string]func(rel.Value) (rel.Value, error){
+	"string": stdConvertString,
+	"number": stdConvertNumber,
+	"bytes":  stdConvertBytes,
+}
+
+func stdConvert() rel.Attr {
+	return rel.NewTupleAttr("convert",
+		rel.NewNativeFunctionAttr("string", stdConvertString),
+		rel.NewNativeFunctionAttr("number", stdConvertNumber),
+		rel.NewNativeFunctionAttr("bytes", stdConvertBytes),
+	)
+}
+
+func stdConvertString(v rel.Value) (rel.Value, error) {
+	switch v := v.(type) {
+	case rel.String:
+		return v, nil
+	case rel.Number:
+		return rel.NewString([]rune(v.String())), nil
+	case rel.Bytes:
+		return rel.NewString([]rune(string(v.Bytes()))), nil
+	}
+	return nil, fmt.Errorf("//convert.string: cannot convert %v to string", v)
+}
+
+func stdConvertNumber(v rel.Value) (rel.Value, error) {
+	switch v := v.(type) {
+	case rel.Number:
+		return v, nil
+	case rel.String:
+		f, err := strconv.ParseFloat(v.String(), 64)
+		if err != nil {
+			return nil, fmt.Errorf("//convert.number: %v", err)
+		}
+		return rel.NewNumber(f), nil
+	}
+	return nil, fmt.Errorf("//convert.number: cannot convert %v to number", v)
+}
+
+func stdConvertBytes(v rel.Value) (rel.Value, error) {
+	switch v := v.(type) {
+	case rel.Bytes:
+		return v, nil
+	case rel.String:
+		return rel.NewBytes([]byte(v.String())), nil
+	case rel.Number:
+		return rel.NewBytes([]byte(v.String())), nil
+	}
+	return nil, fmt.Errorf("//convert.bytes: cannot convert %v to bytes", v)
+}
@@ -0,0 +1,27 @@
+package syntax
+
+import "testing"
+
+func TestConvertString(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `"42"`, `//convert.string(42)`)
+	AssertCodesEvalToSameValue(t, `"abc"`, `//convert.string(<<'a', 'b', 'c'>>)`)
+	AssertCodesEvalToSameValue(t, `"abc"`, `//convert.string("abc")`)
+}
+
+func TestConvertNumber(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `3.5`, `//convert.number("3.5")`)
+	AssertCodesEvalToSameValue(t, `42`, `//convert.number(42)`)
+
+	AssertCodeErrors(t, "//convert.number:", `//convert.number("not a number")`)
+}
+
+func TestConvertBytes(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `<<'a', 'b', 'c'>>`, `//convert.bytes("abc")`)
+	AssertCodesEvalToSameValue(t, `<<'4', '2'>>`, `//convert.bytes(42)`)
+}
@@ -1,6 +1,8 @@
 package syntax
 
 import (
+	"fmt"
+
 	"github.com/arr-ai/arrai/rel"
 )
 
@@ -14,5 +16,27 @@ func stdRel() rel.Attr {
 			}
 			return rel.NUnion(sets...), nil
 		}),
+		rel.NewNativeFunctionAttr("merge", stdRelMerge),
 	)
 }
+
+// stdRelMerge merges an array of tuples into one, with attributes from
+// later tuples overriding those from earlier ones.
+func stdRelMerge(v rel.Value) (rel.Value, error) {
+	arr, ok := rel.AsArray(v)
+	if !ok {
+		return nil, fmt.Errorf("//rel.merge: argument must be an array of tuples, not %v", v)
+	}
+	result := rel.Tuple(rel.EmptyTuple)
+	for _, item := range arr.Values() {
+		t, ok := item.(rel.Tuple)
+		if !ok {
+			return nil, fmt.Errorf("//rel.merge: element must be a tuple, not %v", item)
+		}
+		for e := t.Enumerator(); e.MoveNext(); {
+			name, value := e.Current()
+			result = result.With(name, value)
+		}
+	}
+	return result, nil
+}
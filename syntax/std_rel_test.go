@@ -10,3 +10,14 @@ func TestRelUnion(t *testing.T) {
 	AssertCodesEvalToSameValue(t, `{1}         `, `//rel.union({{1}, {1}, {1}})         `)
 	AssertCodesEvalToSameValue(t, `{}          `, `//rel.union({})                      `)
 }
+
+func TestRelMerge(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `(a: 1, b: 2)`, `//rel.merge([(a: 1), (b: 2)])`)
+	AssertCodesEvalToSameValue(t, `(a: 2, b: 3)`, `//rel.merge([(a: 1, b: 2), (a: 2, b: 3)])`)
+	AssertCodesEvalToSameValue(t, `(a: 1, b: 3, c: 4)`, `//rel.merge([(a: 1), (b: 2, c: 4), (b: 3)])`)
+	AssertCodesEvalToSameValue(t, `(a: 1)`, `//rel.merge([(a: 1)])`)
+
+	AssertCodeErrors(t, "//rel.merge: element must be a tuple", `//rel.merge([(a: 1), 2])`)
+}
@@ -322,10 +322,48 @@ func stdSeq() rel.Attr {
 		createFunc2Attr("has_prefix", stdSeqHasPrefix),
 		createFunc2Attr("has_suffix", stdSeqHasSuffix),
 		createFunc2Attr("join", stdSeqJoin),
+		createFunc3Attr("range", stdSeqRange),
 		rel.NewNativeFunctionAttr("repeat", stdSeqRepeat),
 		createFunc3Attr("sub", stdSeqSub),
 		createFunc2Attr("split", stdSeqSplit),
 		createFunc2Attr("trim_prefix", stdSeqTrimPrefix),
 		createFunc2Attr("trim_suffix", stdSeqTrimSuffix),
+		createFunc2Attr("union", stdSeqUnion),
+		createFunc2Attr("intersect", stdSeqIntersect),
+		createFunc2Attr("diff", stdSeqDiff),
 	)
 }
+
+// stdSeqRange builds an array [from, from+step, from+2*step, ...] stopping at
+// the last value that does not pass to (exclusive of to, consistent with
+// ascending step for positive steps and descending for negative steps). A
+// zero step is an error, since it would never reach to.
+func stdSeqRange(from, to, step rel.Value) (rel.Value, error) {
+	fromNum, is := from.(rel.Number)
+	if !is {
+		return nil, fmt.Errorf("//seq.range: from not a number: %v", from)
+	}
+	toNum, is := to.(rel.Number)
+	if !is {
+		return nil, fmt.Errorf("//seq.range: to not a number: %v", to)
+	}
+	stepNum, is := step.(rel.Number)
+	if !is {
+		return nil, fmt.Errorf("//seq.range: step not a number: %v", step)
+	}
+	f, t, s := fromNum.Float64(), toNum.Float64(), stepNum.Float64()
+	if s == 0 {
+		return nil, fmt.Errorf("//seq.range: step must not be zero")
+	}
+	var values []rel.Value
+	if s > 0 {
+		for v := f; v < t; v += s {
+			values = append(values, rel.NewNumber(v))
+		}
+	} else {
+		for v := f; v > t; v += s {
+			values = append(values, rel.NewNumber(v))
+		}
+	}
+	return rel.NewArray(values...), nil
+}
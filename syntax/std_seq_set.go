@@ -0,0 +1,82 @@
+package syntax
+
+import (
+	"fmt"
+
+	"github.com/arr-ai/arrai/rel"
+)
+
+// arrayMultisetOp implements the shared scan-and-match logic behind
+// //seq.union, //seq.intersect and //seq.diff: walk `a` in order, and for
+// each element decide whether to keep it based on whether (and how many
+// times) it has been consumed from `b` so far.
+func arrayMultisetOp(name string, keep func(inB bool) bool) func(a, b rel.Value) (rel.Value, error) {
+	return func(a, b rel.Value) (rel.Value, error) {
+		arrA, is := rel.AsArray(a)
+		if !is {
+			return nil, fmt.Errorf("//seq.%s: not an array: %v", name, a)
+		}
+		arrB, is := rel.AsArray(b)
+		if !is {
+			return nil, fmt.Errorf("//seq.%s: not an array: %v", name, b)
+		}
+
+		remaining := append([]rel.Value(nil), arrB.Values()...)
+		result := make([]rel.Value, 0, len(arrA.Values()))
+		for _, v := range arrA.Values() {
+			consumedIndex := -1
+			for i, r := range remaining {
+				if r != nil && r.Equal(v) {
+					consumedIndex = i
+					break
+				}
+			}
+			if keep(consumedIndex >= 0) {
+				result = append(result, v)
+			}
+			if consumedIndex >= 0 {
+				remaining[consumedIndex] = nil
+			}
+		}
+		return rel.NewArray(result...), nil
+	}
+}
+
+// stdSeqUnion computes the order-preserving multiset union of two arrays: a
+// followed by the elements of b that don't already have a match consumed
+// from a.
+func stdSeqUnion(a, b rel.Value) (rel.Value, error) {
+	arrA, is := rel.AsArray(a)
+	if !is {
+		return nil, fmt.Errorf("//seq.union: not an array: %v", a)
+	}
+	arrB, is := rel.AsArray(b)
+	if !is {
+		return nil, fmt.Errorf("//seq.union: not an array: %v", b)
+	}
+
+	remaining := append([]rel.Value(nil), arrB.Values()...)
+	for _, v := range arrA.Values() {
+		for i, r := range remaining {
+			if r != nil && r.Equal(v) {
+				remaining[i] = nil
+				break
+			}
+		}
+	}
+	result := append([]rel.Value(nil), arrA.Values()...)
+	for _, v := range remaining {
+		if v != nil {
+			result = append(result, v)
+		}
+	}
+	return rel.NewArray(result...), nil
+}
+
+// stdSeqIntersect keeps only elements of a that also have a corresponding,
+// not-yet-consumed match in b.
+var stdSeqIntersect = arrayMultisetOp("intersect", func(inB bool) bool { return inB })
+
+// stdSeqDiff keeps only elements of a that have no corresponding,
+// not-yet-consumed match in b.
+var stdSeqDiff = arrayMultisetOp("diff", func(inB bool) bool { return !inB })
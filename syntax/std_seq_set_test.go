@@ -0,0 +1,31 @@
+package syntax
+
+import "testing"
+
+func TestSeqUnion(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `[1, 2, 3, 2, 4]`, `//seq.union([1, 2, 3, 2], [2, 4])`)
+	AssertCodesEvalToSameValue(t, `[1, 2]`, `//seq.union([1, 2], [])`)
+	AssertCodesEvalToSameValue(t, `[1, 2]`, `//seq.union([], [1, 2])`)
+
+	AssertCodeErrors(t, "", `//seq.union(1, [1])`)
+}
+
+func TestSeqIntersect(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `[2, 2]`, `//seq.intersect([1, 2, 3, 2], [2, 2, 4])`)
+	AssertCodesEvalToSameValue(t, `[]`, `//seq.intersect([1, 2], [])`)
+
+	AssertCodeErrors(t, "", `//seq.intersect(1, [1])`)
+}
+
+func TestSeqDiff(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `[1, 3]`, `//seq.diff([1, 2, 3, 2], [2, 2, 4])`)
+	AssertCodesEvalToSameValue(t, `[1, 2]`, `//seq.diff([1, 2], [])`)
+
+	AssertCodeErrors(t, "", `//seq.diff(1, [1])`)
+}
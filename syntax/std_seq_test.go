@@ -38,3 +38,15 @@ func TestSeqRepeat(t *testing.T) {
 
 	AssertCodeErrors(t, "", `//seq.repeat(2, 3.4)`)
 }
+
+func TestSeqRange(t *testing.T) {
+	t.Parallel()
+
+	AssertCodesEvalToSameValue(t, `[0, 1, 2, 3, 4]`, `//seq.range(0, 5, 1)`)
+	AssertCodesEvalToSameValue(t, `[5, 4, 3, 2, 1]`, `//seq.range(5, 0, -1)`)
+	AssertCodesEvalToSameValue(t, `[0, 0.25, 0.5, 0.75]`, `//seq.range(0, 1, 0.25)`)
+	AssertCodesEvalToSameValue(t, `[]`, `//seq.range(0, 0, 1)`)
+
+	AssertCodeErrors(t, "//seq.range: step must not be zero", `//seq.range(0, 5, 0)`)
+	AssertCodeErrors(t, "", `//seq.range("a", 5, 1)`)
+}
@@ -8,8 +8,26 @@ import (
 	"github.com/arr-ai/arrai/tools"
 )
 
+// groupSepMarker brackets a thousands-separator character prepended to a
+// format string by compileExpandableString when the xstr `,` flag is used,
+// e.g. "\x00,\x00d" requests "," grouping with the "d" verb. It's stripped
+// before the format reaches fmt.Sprintf, keeping //str.expand's public
+// 4-argument signature unchanged.
+const groupSepMarker = "\x00"
+
+// splitGroupSep extracts a groupSepMarker-wrapped separator prepended to
+// format, returning the separator (empty if none) and the remaining format.
+func splitGroupSep(format string) (string, string) {
+	if strings.HasPrefix(format, groupSepMarker) {
+		if end := strings.Index(format[1:], groupSepMarker); end >= 0 {
+			return format[1 : end+1], format[end+2:]
+		}
+	}
+	return "", format
+}
+
 // TODO: Make this more robust.
-func formatValue(format string, value rel.Value) string {
+func formatValue(format string, value rel.Value, groupSep string) string {
 	var v interface{}
 	switch set := value.(type) {
 	case rel.Set:
@@ -33,7 +51,44 @@ func formatValue(format string, value rel.Value) string {
 			v = int(f)
 		}
 	}
-	return fmt.Sprintf(format, v)
+	s := fmt.Sprintf(format, v)
+	if groupSep != "" {
+		s = groupThousands(s, groupSep)
+	}
+	return s
+}
+
+// groupThousands inserts sep every three digits of s's integer part, e.g.
+// groupThousands("1234567.5", ",") is "1,234,567.5". s is returned unchanged
+// if its integer part isn't purely decimal digits (e.g. hex or %t output).
+func groupThousands(s, sep string) string {
+	neg := strings.HasPrefix(s, "-")
+	digits := strings.TrimPrefix(s, "-")
+	intPart, frac := digits, ""
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		intPart, frac = digits[:i], digits[i:]
+	}
+	if intPart == "" || strings.IndexFunc(intPart, func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+		return s
+	}
+	if len(intPart) <= 3 {
+		return s
+	}
+	var sb strings.Builder
+	lead := len(intPart) % 3
+	if lead == 0 {
+		lead = 3
+	}
+	sb.WriteString(intPart[:lead])
+	for i := lead; i < len(intPart); i += 3 {
+		sb.WriteString(sep)
+		sb.WriteString(intPart[i : i+3])
+	}
+	result := sb.String() + frac
+	if neg {
+		return "-" + result
+	}
+	return result
 }
 
 var (
@@ -42,6 +97,7 @@ var (
 		if !is {
 			return nil, fmt.Errorf("//str.expand: format not a string: %v", args[0])
 		}
+		groupSep, format := splitGroupSep(format)
 		if format != "" {
 			format = "%" + format
 		} else {
@@ -61,15 +117,15 @@ var (
 						sb.WriteString(delim[1:])
 					}
 					if value != nil {
-						sb.WriteString(formatValue(format, value))
+						sb.WriteString(formatValue(format, value, groupSep))
 					}
 				}
 				s = sb.String()
 			} else {
-				return nil, fmt.Errorf("//str..expand: arg not an array in ${arg::}: %v", args[1])
+				return nil, fmt.Errorf("//str.expand: arg not an array in ${arg::}: %v", args[1])
 			}
 		} else {
-			s = formatValue(format, args[1])
+			s = formatValue(format, args[1], groupSep)
 		}
 		if s != "" {
 			tail, is := tools.ValueAsString(args[3])